@@ -15,6 +15,42 @@ type Resource struct {
 	metav1.GroupVersionKind `json:",inline"`
 }
 
+// ResourceReadiness is the readiness of a Resource that has a readiness rule, e.g. a workload.
+// +k8s:deepcopy-gen=true
+type ResourceReadiness struct {
+	Resource `json:",inline"`
+	Ready    bool `json:"ready"`
+	// Message explains why the Resource is not ready.
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Conflict;Forbidden;Invalid;NotFoundMapping;Other
+type ResourceErrorKind string
+
+const (
+	// ResourceErrorConflict is a conflict with the fields managed by other field managers or a concurrent update.
+	ResourceErrorConflict ResourceErrorKind = "Conflict"
+	// ResourceErrorForbidden is a Resource that may not be applied with the permissions of the reconciler.
+	ResourceErrorForbidden ResourceErrorKind = "Forbidden"
+	// ResourceErrorInvalid is a Resource that is rejected by the validation of the API server.
+	ResourceErrorInvalid ResourceErrorKind = "Invalid"
+	// ResourceErrorNotFoundMapping is a Resource of a kind that the API server does not serve.
+	ResourceErrorNotFoundMapping ResourceErrorKind = "NotFoundMapping"
+	// ResourceErrorOther is any other error, e.g. a timeout.
+	ResourceErrorOther ResourceErrorKind = "Other"
+)
+
+// ResourceError is the error that occurred while applying a Resource.
+// +k8s:deepcopy-gen=true
+type ResourceError struct {
+	Resource  `json:",inline"`
+	ErrorKind ResourceErrorKind `json:"errorKind"`
+	Message   string            `json:"message"`
+	// ConflictingManagers are the field managers of other controllers that manage conflicting fields.
+	// +listType=atomic
+	ConflictingManagers []string `json:"conflictingManagers,omitempty"`
+}
+
 func (r Resource) ToUnstructured() *unstructured.Unstructured {
 	obj := unstructured.Unstructured{}
 	obj.SetGroupVersionKind(schema.GroupVersionKind(r.GroupVersionKind))
@@ -24,8 +24,24 @@ type Status struct {
 	// All resources that are synced are considered for orphan removal on configuration changes,
 	// and it is used to determine effective differences from one state to the next.
 	// +listType=atomic
-	Synced        []Resource `json:"synced,omitempty"`
-	LastOperation `json:"lastOperation,omitempty"`
+	Synced []Resource `json:"synced,omitempty"`
+
+	// Orphaned lists the Resources that were dropped from the manifest but are protected from pruning,
+	// so they were left in the cluster instead of being deleted and are no longer synced.
+	// The list is bounded in size, so only the most recently orphaned Resources are kept.
+	// +listType=atomic
+	Orphaned []Resource `json:"orphaned,omitempty"`
+
+	// Readiness summarizes the readiness of the synced Resources that have a readiness rule,
+	// e.g. workloads, Jobs, PersistentVolumeClaims, CustomResourceDefinitions, or APIServices.
+	// +listType=atomic
+	Readiness []ResourceReadiness `json:"readiness,omitempty"`
+
+	// ResourceErrors lists the Resources that failed to be applied in the last synchronization.
+	// The list is bounded in size, so it may not contain all failed Resources.
+	// +listType=atomic
+	ResourceErrors []ResourceError `json:"resourceErrors,omitempty"`
+	LastOperation  `json:"lastOperation,omitempty"`
 }
 
 func (s Status) WithState(state State) Status {
@@ -56,6 +56,43 @@ func (in *Resource) DeepCopy() *Resource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceError) DeepCopyInto(out *ResourceError) {
+	*out = *in
+	out.Resource = in.Resource
+	if in.ConflictingManagers != nil {
+		in, out := &in.ConflictingManagers, &out.ConflictingManagers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceError.
+func (in *ResourceError) DeepCopy() *ResourceError {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReadiness) DeepCopyInto(out *ResourceReadiness) {
+	*out = *in
+	out.Resource = in.Resource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReadiness.
+func (in *ResourceReadiness) DeepCopy() *ResourceReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
@@ -71,6 +108,23 @@ func (in *Status) DeepCopyInto(out *Status) {
 		*out = make([]Resource, len(*in))
 		copy(*out, *in)
 	}
+	if in.Orphaned != nil {
+		in, out := &in.Orphaned, &out.Orphaned
+		*out = make([]Resource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = make([]ResourceReadiness, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceErrors != nil {
+		in, out := &in.ResourceErrors, &out.ResourceErrors
+		*out = make([]ResourceError, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.LastOperation.DeepCopyInto(&out.LastOperation)
 }
 
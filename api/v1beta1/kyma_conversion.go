@@ -22,6 +22,8 @@ func (src *Kyma) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	dst.Spec.Channel = src.Spec.Channel
 	dst.Spec.Modules = src.Spec.Modules
+	dst.Spec.MaintenanceWindows = src.Spec.MaintenanceWindows
+	dst.Spec.OrphanPolicy = src.Spec.OrphanPolicy
 	dst.Status = src.Status
 
 	return nil
@@ -37,6 +39,8 @@ func (dst *Kyma) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.ObjectMeta = src.ObjectMeta
 	dst.Spec.Channel = src.Spec.Channel
 	dst.Spec.Modules = src.Spec.Modules
+	dst.Spec.MaintenanceWindows = src.Spec.MaintenanceWindows
+	dst.Spec.OrphanPolicy = src.Spec.OrphanPolicy
 	if src.HasSyncLabelEnabled() {
 		dst.Spec.Sync.Enabled = true
 	} else {
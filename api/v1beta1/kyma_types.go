@@ -80,6 +80,17 @@ type KymaSpec struct {
 	// Active Synchronization Settings
 	// +optional
 	Sync Sync `json:"sync,omitempty"`
+
+	// MaintenanceWindows restrict module upgrades to the given recurring time windows.
+	// Outside of all windows, changes of the ModuleTemplate used by an already installed module are deferred.
+	// If empty, module upgrades are applied at any time.
+	// +optional
+	MaintenanceWindows []v1beta2.MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// OrphanPolicy determines what happens to installed modules whose ModuleTemplate was deleted.
+	// If not set, the orphan policy configured for Lifecycle Manager is used.
+	// +optional
+	OrphanPolicy *v1beta2.OrphanPolicy `json:"orphanPolicy,omitempty"`
 }
 
 //+kubebuilder:object:root=true
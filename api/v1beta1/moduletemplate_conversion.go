@@ -16,6 +16,8 @@ func (src *ModuleTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Data = src.Spec.Data
 	dst.Spec.Descriptor = src.Spec.Descriptor
 	dst.Spec.CustomStateCheck = src.Spec.CustomStateCheck
+	dst.Spec.Dependencies = src.Spec.Dependencies
+	dst.Spec.Audience = src.Spec.Audience
 	return nil
 }
 
@@ -31,6 +33,8 @@ func (dst *ModuleTemplate) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.Data = src.Spec.Data
 	dst.Spec.Descriptor = src.Spec.Descriptor
 	dst.Spec.CustomStateCheck = src.Spec.CustomStateCheck
+	dst.Spec.Dependencies = src.Spec.Dependencies
+	dst.Spec.Audience = src.Spec.Audience
 	dst.Spec.Target = TargetRemote
 
 	return nil
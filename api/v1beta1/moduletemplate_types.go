@@ -77,6 +77,17 @@ type ModuleTemplateSpec struct {
 	Target Target `json:"target"`
 
 	CustomStateCheck []*v1beta2.CustomStateCheck `json:"customStateCheck,omitempty"`
+
+	// Dependencies lists the modules that have to be installed and Ready before this module is installed.
+	// An entry can reference a module of the same Kyma by its module name label, its name in the Kyma,
+	// or its FQDN. The module is also deleted before any of its dependencies.
+	// +optional
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// Audience selects the Kymas that can use the ModuleTemplate by their labels, e.g. region, plan
+	// or customer tier. A ModuleTemplate without an Audience is available to every Kyma.
+	// +optional
+	Audience *metav1.LabelSelector `json:"audience,omitempty"`
 }
 
 //+kubebuilder:object:root=true
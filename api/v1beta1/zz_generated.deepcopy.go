@@ -22,6 +22,7 @@ package v1beta1
 
 import (
 	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -90,9 +91,23 @@ func (in *KymaSpec) DeepCopyInto(out *KymaSpec) {
 	if in.Modules != nil {
 		in, out := &in.Modules, &out.Modules
 		*out = make([]v1beta2.Module, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	out.Sync = in.Sync
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]v1beta2.MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrphanPolicy != nil {
+		in, out := &in.OrphanPolicy, &out.OrphanPolicy
+		*out = new(v1beta2.OrphanPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KymaSpec.
@@ -241,6 +256,16 @@ func (in *ModuleTemplateSpec) DeepCopyInto(out *ModuleTemplateSpec) {
 			}
 		}
 	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleTemplateSpec.
@@ -11,6 +11,17 @@ const (
 	ConditionTypeModules         KymaConditionType = "Modules"
 	ConditionTypeModuleCatalog   KymaConditionType = "ModuleCatalog"
 	ConditionTypeSKRWebhook      KymaConditionType = "SKRWebhook"
+	// ConditionTypeModuleUpgrades is only informational and does not influence the state of the Kyma.
+	ConditionTypeModuleUpgrades KymaConditionType = "ModuleUpgrades"
+	// ConditionTypeOptionalModules summarizes the optional modules, while ConditionTypeModules only
+	// considers the critical modules. It is only informational and does not influence the state of the Kyma.
+	ConditionTypeOptionalModules KymaConditionType = "OptionalModules"
+	// ConditionTypeDeprecatedChannels warns about modules using deprecated channels and is removed
+	// if no module uses one. It is only informational and does not influence the state of the Kyma.
+	ConditionTypeDeprecatedChannels KymaConditionType = "DeprecatedChannels"
+	// ConditionTypeModuleSources reports whether the module sources besides the control plane are available
+	// and is removed if none are configured. It is only informational and does not influence the state of the Kyma.
+	ConditionTypeModuleSources KymaConditionType = "ModuleSources"
 
 	// ConditionReason will be set to `Ready` on all Conditions. If the Condition is actual ready,
 	// can be determined by the state.
@@ -24,6 +35,22 @@ const (
 	ConditionMessageSKRWebhookIsOutOfSync     = "skrwebhook is out of sync and needs to be resynchronized"
 	ConditionMessageModuleStateUnknown        = "modules state is unknown"
 	ConditionMessageModuleCatalogStateUnknown = "module templates synchronization state is unknown"
+	ConditionMessageModuleUpgradesApplied     = "module upgrades are applied"
+	ConditionMessageModuleUpgradesDeferred    = "module upgrades are deferred until the next maintenance window"
+
+	// ConditionMessageCriticalModuleInReadyState is the message of the Modules condition of a Kyma with optional
+	// modules, which only considers the critical modules since optional ones are summarized in the OptionalModules
+	// condition.
+	ConditionMessageCriticalModuleInReadyState    = "all critical modules are in ready state"
+	ConditionMessageCriticalModuleNotInReadyState = "not all critical modules are in ready state"
+	ConditionMessageOptionalModuleInReadyState    = "all optional modules are in ready state"
+	ConditionMessageOptionalModuleNotInReadyState = "not all optional modules are in ready state"
+
+	ConditionMessageDeprecatedChannelsMigrated = "modules were migrated from deprecated channels to their successors"
+	ConditionMessageDeprecatedChannelsInUse    = "modules use deprecated channels that reach their end of life"
+
+	ConditionMessageModuleSourcesAvailable   = "module sources are available"
+	ConditionMessageModuleSourcesUnavailable = "module sources are unavailable, only the modules of the others are offered"
 )
 
 func GenerateMessage(conditionType KymaConditionType, status metav1.ConditionStatus) string {
@@ -57,12 +84,45 @@ func GenerateMessage(conditionType KymaConditionType, status metav1.ConditionSta
 		}
 
 		return ConditionMessageSKRWebhookIsOutOfSync
+	case ConditionTypeModuleUpgrades:
+		if status == metav1.ConditionTrue {
+			return ConditionMessageModuleUpgradesApplied
+		}
+		return ConditionMessageModuleUpgradesDeferred
+	case ConditionTypeOptionalModules:
+		if status == metav1.ConditionTrue {
+			return ConditionMessageOptionalModuleInReadyState
+		}
+		return ConditionMessageOptionalModuleNotInReadyState
+	case ConditionTypeDeprecatedChannels:
+		if status == metav1.ConditionTrue {
+			return ConditionMessageDeprecatedChannelsMigrated
+		}
+		return ConditionMessageDeprecatedChannelsInUse
+	case ConditionTypeModuleSources:
+		if status == metav1.ConditionTrue {
+			return ConditionMessageModuleSourcesAvailable
+		}
+		return ConditionMessageModuleSourcesUnavailable
 	case DeprecatedConditionTypeReady:
 	}
 
 	return "no detailed message available as condition or status is unknown to API"
 }
 
+// GenerateCriticalModulesMessage returns the message of the Modules condition of a Kyma with optional modules.
+func GenerateCriticalModulesMessage(status metav1.ConditionStatus) string {
+	switch status {
+	case metav1.ConditionTrue:
+		return ConditionMessageCriticalModuleInReadyState
+	case metav1.ConditionUnknown:
+		return ConditionMessageModuleStateUnknown
+	case metav1.ConditionFalse:
+	}
+
+	return ConditionMessageCriticalModuleNotInReadyState
+}
+
 // GetRequiredConditionTypes returns all required ConditionTypes for a KymaCR.
 func GetRequiredConditionTypes(syncEnabled, watcherEnabled bool) []KymaConditionType {
 	requiredConditions := []KymaConditionType{ConditionTypeModules}
@@ -74,3 +134,11 @@ func GetRequiredConditionTypes(syncEnabled, watcherEnabled bool) []KymaCondition
 	}
 	return requiredConditions
 }
+
+// IsInformationalCondition returns true for ConditionTypes that do not influence the state of a KymaCR.
+func IsInformationalCondition(conditionType string) bool {
+	return conditionType == string(ConditionTypeModuleUpgrades) ||
+		conditionType == string(ConditionTypeOptionalModules) ||
+		conditionType == string(ConditionTypeDeprecatedChannels) ||
+		conditionType == string(ConditionTypeModuleSources)
+}
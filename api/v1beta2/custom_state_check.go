@@ -0,0 +1,26 @@
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// StateCheckExpressionVariable is the variable that holds the Module CR in a CustomStateCheck Expression.
+const StateCheckExpressionVariable = "self"
+
+// ValidateCustomStateChecks verifies that every CustomStateCheck either compares the Value at a JSONPath
+// or evaluates an Expression. The Expressions are compiled by the ModuleTemplate webhook of Lifecycle Manager,
+// so that consumers of the API do not depend on CEL.
+func ValidateCustomStateChecks(stateChecks []*CustomStateCheck, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, stateCheck := range stateChecks {
+		checkPath := path.Index(i)
+		switch {
+		case stateCheck.Expression != "" && stateCheck.JSONPath != "":
+			errs = append(errs, field.Invalid(checkPath.Child("expression"), stateCheck.Expression,
+				"expression and jsonPath are mutually exclusive"))
+		case stateCheck.Expression == "" && stateCheck.JSONPath == "":
+			errs = append(errs, field.Required(checkPath.Child("jsonPath"), "either jsonPath or expression is required"))
+		}
+	}
+	return errs
+}
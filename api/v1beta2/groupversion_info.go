@@ -29,7 +29,10 @@ import (
 const (
 	KymaKind           Kind = "Kyma"
 	ModuleTemplateKind Kind = "ModuleTemplate"
+	ModuleRolloutKind  Kind = "ModuleRollout"
 	WatcherKind        Kind = "Watcher"
+
+	ModuleChannelPromotionKind Kind = "ModuleChannelPromotion"
 )
 
 type Kind string
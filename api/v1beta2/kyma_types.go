@@ -22,6 +22,7 @@ import (
 	"github.com/kyma-project/lifecycle-manager/api/shared"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 //+kubebuilder:object:root=true
@@ -50,6 +51,17 @@ type KymaSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	Modules []Module `json:"modules,omitempty"`
+
+	// MaintenanceWindows restrict module upgrades to the given recurring time windows.
+	// Outside of all windows, changes of the ModuleTemplate used by an already installed module are deferred.
+	// If empty, module upgrades are applied at any time.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// OrphanPolicy determines what happens to installed modules whose ModuleTemplate was deleted.
+	// If not set, the orphan policy configured for Lifecycle Manager is used.
+	// +optional
+	OrphanPolicy *OrphanPolicy `json:"orphanPolicy,omitempty"`
 }
 
 // Module defines the components to be installed.
@@ -75,6 +87,13 @@ type Module struct {
 	// +kubebuilder:validation:MinLength:=3
 	Channel string `json:"channel,omitempty"`
 
+	// Version pins the Module to a specific version instead of resolving it through a channel.
+	// It can either be an exact version (e.g. 1.4.2) or a semantic version range (e.g. ~1.4).
+	// If set, the ModuleTemplate is resolved among all ModuleTemplates of the Module, regardless of their channel,
+	// by picking the highest descriptor version that satisfies the given version.
+	// +kubebuilder:validation:MaxLength:=64
+	Version string `json:"version,omitempty"`
+
 	// RemoteModuleTemplateRef is the reference (FQDN, Namespace/Name, Module Name Label)
 	// to the module template on the remote cluster.
 	// If specified, the module template will be fetched from the SKR and reconciled.
@@ -82,6 +101,78 @@ type Module struct {
 
 	// +kubebuilder:default:=CreateAndDelete
 	CustomResourcePolicy `json:"customResourcePolicy,omitempty"`
+
+	// Config overrides the default module CR of the ModuleTemplate (.spec.data) for this Kyma.
+	// +optional
+	Config *ModuleConfig `json:"config,omitempty"`
+
+	// Criticality determines how the state of the Module influences the state of the Kyma.
+	// If not set, the criticality annotation of the ModuleTemplate is used, which defaults to Critical.
+	// +optional
+	Criticality ModuleCriticality `json:"criticality,omitempty"`
+
+	// Paused freezes the Module in its current state. The Manifest of a paused Module is neither updated nor
+	// deleted, and the resources of the Manifest are neither applied nor pruned until the Module is resumed.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// TargetNamespace installs the namespaced resources of the Module into the given namespace instead of the
+	// namespaces of its manifest. Cluster-scoped resources are not changed.
+	// +optional
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength:=63
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+}
+
+// ModuleCriticality determines how the state of a Module influences the state of the Kyma.
+// +kubebuilder:validation:Enum=Critical;Optional;""
+type ModuleCriticality string
+
+const (
+	// ModuleCritical modules determine the state of the Kyma, e.g. a critical Module in Error puts the Kyma in Error.
+	ModuleCritical ModuleCriticality = "Critical"
+	// ModuleOptional modules in Error only put the Kyma in Warning.
+	ModuleOptional ModuleCriticality = "Optional"
+)
+
+// ModuleConfig contains overrides that are patched into the default module CR of the ModuleTemplate
+// before it is written to the Manifest. Exactly one of Inline or ConfigMapRef has to be set.
+// The overrides end up in plain text in the Manifest and the module CR, so they must not contain credentials.
+type ModuleConfig struct {
+	// PatchType determines how the overrides are applied to the default module CR.
+	// A MergePatch (RFC 7386) is merged into the default module CR, a JSONPatch (RFC 6902) is a list of
+	// operations applied to it.
+	// +kubebuilder:default:=MergePatch
+	PatchType ModuleConfigPatchType `json:"patchType,omitempty"`
+
+	// Inline contains the overrides directly.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	Inline *runtime.RawExtension `json:"inline,omitempty"`
+
+	// ConfigMapRef references a key of a ConfigMap in the namespace of the Kyma containing the overrides
+	// as JSON or YAML.
+	// +optional
+	ConfigMapRef *ModuleConfigKeyRef `json:"configMapRef,omitempty"`
+}
+
+// ModuleConfigPatchType determines how the overrides of a ModuleConfig are applied.
+// +kubebuilder:validation:Enum=MergePatch;JSONPatch
+type ModuleConfigPatchType string
+
+const (
+	ModuleConfigMergePatch ModuleConfigPatchType = "MergePatch"
+	ModuleConfigJSONPatch  ModuleConfigPatchType = "JSONPatch"
+)
+
+// ModuleConfigKeyRef references a key of a ConfigMap.
+type ModuleConfigKeyRef struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key in the data of the ConfigMap.
+	Key string `json:"key"`
 }
 
 // CustomResourcePolicy determines how a ModuleTemplate should be parsed. When CustomResourcePolicy is set to
@@ -139,6 +230,11 @@ type KymaStatus struct {
 	// +optional
 	ActiveChannel string `json:"activeChannel,omitempty"`
 
+	// Catalog lists the modules that can be enabled in the Kyma, with the versions offered in each channel.
+	// It is computed from the ModuleTemplates visible to the Kyma.
+	// +optional
+	Catalog []CatalogModule `json:"catalog,omitempty"`
+
 	shared.LastOperation `json:"lastOperation,omitempty"`
 }
 
@@ -162,21 +258,79 @@ type ModuleStatus struct {
 
 	// Channel tracks the active Channel of the Module. In Case it changes, the new Channel will have caused
 	// a new lookup to be necessary that maybe picks a different ModuleTemplate, which is why we need to reconcile.
+	// It is the Channel whose ModuleTemplate satisfied the request, which can differ from the requested Channel.
 	Channel string `json:"channel,omitempty"`
 
+	// RequestedChannel is the Channel requested for the Module, if it is satisfied by a different Channel
+	// because it is an alias or fell back to another Channel.
+	// +optional
+	RequestedChannel string `json:"requestedChannel,omitempty"`
+
 	// Channel tracks the active Version of the Module.
 	Version string `json:"version,omitempty"`
 
+	// ResolvedBy tracks if the ModuleTemplate of the Module was resolved by its Channel or by a Version pin.
+	// +optional
+	ResolvedBy ModuleResolution `json:"resolvedBy,omitempty"`
+
+	// RolledBackFrom is the Version the Module was rolled back from, if the active Version is the result
+	// of a rollback permitted by the ModuleTemplate.
+	// +optional
+	RolledBackFrom string `json:"rolledBackFrom,omitempty"`
+
 	// Message is a human-readable message indicating details about the State.
 	Message string `json:"message,omitempty"`
 
 	// State of the Module in the currently tracked Generation
 	State shared.State `json:"state"`
 
+	// Criticality is the resolved criticality of the Module, which determines how State influences the
+	// state of the Kyma. An empty Criticality is treated as Critical.
+	// +optional
+	Criticality ModuleCriticality `json:"criticality,omitempty"`
+
 	// Resource contains information about the created module CR.
 	Resource *TrackingObject `json:"resource,omitempty"`
+
+	// OrphanedSince is when the ModuleTemplate of the installed Module was found to be deleted.
+	// +optional
+	OrphanedSince *metav1.Time `json:"orphanedSince,omitempty"`
+
+	// History contains the latest Version and Channel transitions of the Module, the most recent one first.
+	// It is bounded to MaxModuleHistory entries.
+	// +optional
+	History []ModuleTransition `json:"history,omitempty"`
 }
 
+// MaxModuleHistory is the number of transitions kept in the History of a ModuleStatus.
+const MaxModuleHistory = 5
+
+// ModuleTransition records that a Module was installed in or changed to a Version and Channel.
+type ModuleTransition struct {
+	// Version is the Version the Module transitioned to.
+	Version string `json:"version"`
+
+	// Channel is the Channel the Module transitioned to.
+	Channel string `json:"channel,omitempty"`
+
+	// TemplateGeneration is the generation of the ModuleTemplate the Module transitioned with.
+	TemplateGeneration int64 `json:"templateGeneration,omitempty"`
+
+	// TransitionTime is when the transition was observed.
+	TransitionTime metav1.Time `json:"transitionTime"`
+}
+
+// ModuleResolution determines how the ModuleTemplate of a Module was resolved.
+// +kubebuilder:validation:Enum=Channel;Version;""
+type ModuleResolution string
+
+const (
+	// ModuleResolvedByChannel means the ModuleTemplate was picked based on the desired channel of the Module.
+	ModuleResolvedByChannel ModuleResolution = "Channel"
+	// ModuleResolvedByVersion means the ModuleTemplate was picked based on the pinned Version of the Module.
+	ModuleResolvedByVersion ModuleResolution = "Version"
+)
+
 // TrackingObject contains metav1.TypeMeta and PartialMeta to allow a generation based object tracking.
 // It purposefully does not use ObjectMeta as the generation of controller-runtime for crds would not validate
 // the generation fields even when embedding ObjectMeta.
@@ -240,6 +394,8 @@ func (m PartialMeta) GetGeneration() int64 {
 // Type: Modules, Reason: Ready and Status: True means all modules are in ready state.
 // Type: Modules, Reason: Ready and Status: False means some modules are not in ready state,
 // and the actual state of individual module can be found in related ModuleStatus.
+// If a Kyma has optional modules, they are summarized in the OptionalModules condition instead,
+// and the Modules condition only considers the critical modules.
 type KymaConditionType string
 
 // KymaConditionMsg represents the current state of a condition in a human-readable format.
@@ -298,11 +454,26 @@ func init() {
 }
 
 func (kyma *Kyma) UpdateCondition(conditionType KymaConditionType, status metav1.ConditionStatus) {
+	kyma.UpdateConditionWithDetails(conditionType, status, "")
+}
+
+// UpdateConditionWithDetails sets the condition like UpdateCondition, but appends the details to its message.
+func (kyma *Kyma) UpdateConditionWithDetails(conditionType KymaConditionType, status metav1.ConditionStatus,
+	details string,
+) {
+	message := GenerateMessage(conditionType, status)
+	if details != "" {
+		message += ": " + details
+	}
+	kyma.setCondition(conditionType, status, message)
+}
+
+func (kyma *Kyma) setCondition(conditionType KymaConditionType, status metav1.ConditionStatus, message string) {
 	meta.SetStatusCondition(&kyma.Status.Conditions, metav1.Condition{
 		Type:               string(conditionType),
 		Status:             status,
 		Reason:             string(ConditionReason),
-		Message:            GenerateMessage(conditionType, status),
+		Message:            message,
 		ObservedGeneration: kyma.GetGeneration(),
 	})
 }
@@ -326,12 +497,23 @@ func (kyma *Kyma) ContainsCondition(conditionType KymaConditionType, conditionSt
 	return false
 }
 
+// IsOptional returns true if the state of the Module does not determine the state of the Kyma alone.
+func (m *ModuleStatus) IsOptional() bool {
+	return m.Criticality == ModuleOptional
+}
+
+// DetermineState aggregates the states of all modules and the conditions of the Kyma.
+// Optional modules in Error only put the Kyma in Warning.
 func (kyma *Kyma) DetermineState() shared.State {
 	status := &kyma.Status
 	stateMap := map[shared.State]bool{}
 	for _, moduleStatus := range status.Modules {
 		if moduleStatus.State == shared.StateError {
-			stateMap[shared.StateError] = true
+			if moduleStatus.IsOptional() {
+				stateMap[shared.StateWarning] = true
+			} else {
+				stateMap[shared.StateError] = true
+			}
 		}
 		if moduleStatus.State == shared.StateWarning {
 			stateMap[shared.StateWarning] = true
@@ -351,7 +533,7 @@ func (kyma *Kyma) DetermineState() shared.State {
 	}
 
 	for _, condition := range status.Conditions {
-		if condition.Status != metav1.ConditionTrue {
+		if condition.Status != metav1.ConditionTrue && !IsInformationalCondition(condition.Type) {
 			return shared.StateProcessing
 		}
 	}
@@ -369,6 +551,38 @@ func (kyma *Kyma) AllModulesReady() bool {
 	return true
 }
 
+// UpdateModuleConditions summarizes the health of the critical modules in the Modules condition and of the
+// optional modules in the informational OptionalModules condition, which is removed if there is no optional module.
+func (kyma *Kyma) UpdateModuleConditions() {
+	criticalReady, optionalReady, hasOptional := true, true, false
+	for i := range kyma.Status.Modules {
+		moduleStatus := &kyma.Status.Modules[i]
+		ready := moduleStatus.State == shared.StateReady
+		if moduleStatus.IsOptional() {
+			hasOptional = true
+			optionalReady = optionalReady && ready
+		} else {
+			criticalReady = criticalReady && ready
+		}
+	}
+
+	if !hasOptional {
+		kyma.UpdateCondition(ConditionTypeModules, conditionStatus(criticalReady))
+		meta.RemoveStatusCondition(&kyma.Status.Conditions, string(ConditionTypeOptionalModules))
+		return
+	}
+	kyma.setCondition(ConditionTypeModules, conditionStatus(criticalReady),
+		GenerateCriticalModulesMessage(conditionStatus(criticalReady)))
+	kyma.UpdateCondition(ConditionTypeOptionalModules, conditionStatus(optionalReady))
+}
+
+func conditionStatus(ready bool) metav1.ConditionStatus {
+	if ready {
+		return metav1.ConditionTrue
+	}
+	return metav1.ConditionFalse
+}
+
 const (
 	EnableLabelValue  = "true"
 	DisableLabelValue = "false"
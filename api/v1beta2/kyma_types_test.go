@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-project/lifecycle-manager/api/shared"
 	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
 	"github.com/kyma-project/lifecycle-manager/pkg/testutils"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -69,3 +70,49 @@ func TestKyma_DetermineState(t *testing.T) {
 		})
 	}
 }
+
+func TestKyma_DetermineState_OptionalModules(t *testing.T) {
+	t.Parallel()
+	kyma := testutils.NewTestKyma("test-kyma")
+	kyma.Status.Modules = []v1beta2.ModuleStatus{
+		{Name: "critical", State: shared.StateReady},
+		{Name: "optional", State: shared.StateError, Criticality: v1beta2.ModuleOptional},
+	}
+	kyma.UpdateModuleConditions()
+
+	if got := kyma.DetermineState(); got != shared.StateWarning {
+		t.Errorf("DetermineState() = %v, want %v", got, shared.StateWarning)
+	}
+	if !kyma.ContainsCondition(v1beta2.ConditionTypeModules, metav1.ConditionTrue) {
+		t.Errorf("expected condition %s to be true", v1beta2.ConditionTypeModules)
+	}
+	if !kyma.ContainsCondition(v1beta2.ConditionTypeOptionalModules, metav1.ConditionFalse) {
+		t.Errorf("expected condition %s to be false", v1beta2.ConditionTypeOptionalModules)
+	}
+	if got := modulesConditionMessage(kyma); got != v1beta2.ConditionMessageCriticalModuleInReadyState {
+		t.Errorf("expected message %q of condition %s, got %q", v1beta2.ConditionMessageCriticalModuleInReadyState,
+			v1beta2.ConditionTypeModules, got)
+	}
+
+	kyma.Status.Modules[1].Criticality = v1beta2.ModuleCritical
+	kyma.UpdateModuleConditions()
+
+	if got := kyma.DetermineState(); got != shared.StateError {
+		t.Errorf("DetermineState() = %v, want %v", got, shared.StateError)
+	}
+	if kyma.ContainsCondition(v1beta2.ConditionTypeOptionalModules) {
+		t.Errorf("expected condition %s to be removed", v1beta2.ConditionTypeOptionalModules)
+	}
+	if got := modulesConditionMessage(kyma); got != v1beta2.ConditionMessageModuleNotInReadyState {
+		t.Errorf("expected message %q of condition %s, got %q", v1beta2.ConditionMessageModuleNotInReadyState,
+			v1beta2.ConditionTypeModules, got)
+	}
+}
+
+func modulesConditionMessage(kyma *v1beta2.Kyma) string {
+	condition := meta.FindStatusCondition(kyma.Status.Conditions, string(v1beta2.ConditionTypeModules))
+	if condition == nil {
+		return ""
+	}
+	return condition.Message
+}
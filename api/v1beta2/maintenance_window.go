@@ -0,0 +1,141 @@
+package v1beta2
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var ErrInvalidMaintenanceWindow = errors.New("invalid maintenance window")
+
+// MaxMaintenanceWindowDuration bounds the Duration of a MaintenanceWindow, as a window opens at least once a week.
+const MaxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+// MaintenanceWindow is a recurring time window in which module upgrades are applied.
+type MaintenanceWindow struct {
+	// Days are the days of the week the window opens on. If empty, the window opens every day.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the time of day the window opens, in the format HH:MM.
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open after it opened, at most 168h.
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of Start, e.g. Europe/Berlin. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ValidateMaintenanceWindows verifies that every MaintenanceWindow stays open for a positive Duration
+// of at most MaxMaintenanceWindowDuration.
+func ValidateMaintenanceWindows(windows []MaintenanceWindow, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, window := range windows {
+		if window.Duration.Duration <= 0 || window.Duration.Duration > MaxMaintenanceWindowDuration {
+			errs = append(errs, field.Invalid(path.Index(i).Child("duration"), window.Duration.String(),
+				fmt.Sprintf("duration must be positive and at most %s", MaxMaintenanceWindowDuration)))
+		}
+	}
+	return errs
+}
+
+// IsOpen returns true if the window is open at the given time.
+func (w MaintenanceWindow) IsOpen(now time.Time) (bool, error) {
+	location, start, err := w.parse()
+	if err != nil {
+		return false, err
+	}
+	now = now.In(location)
+	// the window may have opened on one of the previous days and still be open.
+	for days := 0; days <= int(w.Duration.Hours()/24)+1; days++ {
+		day := now.AddDate(0, 0, -days)
+		if !w.opensOn(day.Weekday()) {
+			continue
+		}
+		opening := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, location)
+		if !now.Before(opening) && now.Before(opening.Add(w.Duration.Duration)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NextOpening returns the time the window opens next after the given time.
+func (w MaintenanceWindow) NextOpening(now time.Time) (time.Time, error) {
+	location, start, err := w.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+	now = now.In(location)
+	const daysPerWeek = 7
+	for days := 0; days <= daysPerWeek; days++ {
+		day := now.AddDate(0, 0, days)
+		if !w.opensOn(day.Weekday()) {
+			continue
+		}
+		opening := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, location)
+		if opening.After(now) {
+			return opening, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: window never opens", ErrInvalidMaintenanceWindow)
+}
+
+func (w MaintenanceWindow) parse() (*time.Location, time.Time, error) {
+	location, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: %w", ErrInvalidMaintenanceWindow, err)
+	}
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: %w", ErrInvalidMaintenanceWindow, err)
+	}
+	return location, start, nil
+}
+
+func (w MaintenanceWindow) opensOn(weekday time.Weekday) bool {
+	return len(w.Days) == 0 || slices.Contains(w.Days, Weekday(weekday.String()))
+}
+
+// IsInMaintenanceWindow returns true if any maintenance window of the Kyma is open at the given time,
+// or if the Kyma does not define any maintenance windows.
+func (kyma *Kyma) IsInMaintenanceWindow(now time.Time) (bool, error) {
+	if len(kyma.Spec.MaintenanceWindows) == 0 {
+		return true, nil
+	}
+	for _, window := range kyma.Spec.MaintenanceWindows {
+		open, err := window.IsOpen(now)
+		if err != nil {
+			return false, err
+		}
+		if open {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NextMaintenanceWindow returns the time the next maintenance window of the Kyma opens after the given time.
+func (kyma *Kyma) NextMaintenanceWindow(now time.Time) (time.Time, error) {
+	var next time.Time
+	for _, window := range kyma.Spec.MaintenanceWindows {
+		opening, err := window.NextOpening(now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if next.IsZero() || opening.Before(next) {
+			next = opening
+		}
+	}
+	return next, nil
+}
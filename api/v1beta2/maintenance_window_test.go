@@ -0,0 +1,78 @@
+package v1beta2_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+func TestMaintenanceWindow_IsOpen(t *testing.T) {
+	t.Parallel()
+	window := v1beta2.MaintenanceWindow{
+		Days:     []v1beta2.Weekday{"Saturday"},
+		Start:    "22:00",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+		TimeZone: "Europe/Berlin",
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"before opening", time.Date(2023, 11, 4, 21, 59, 0, 0, berlin), false},
+		{"at opening", time.Date(2023, 11, 4, 22, 0, 0, 0, berlin), true},
+		{"after midnight of the opening day", time.Date(2023, 11, 5, 1, 30, 0, 0, berlin), true},
+		{"after closing", time.Date(2023, 11, 5, 2, 0, 0, 0, berlin), false},
+		{"in other time zone", time.Date(2023, 11, 4, 21, 30, 0, 0, time.UTC), true},
+		{"on other day", time.Date(2023, 11, 3, 23, 0, 0, 0, berlin), false},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			open, err := window.IsOpen(testCase.now)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, open)
+		})
+	}
+}
+
+func TestKyma_NextMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+	kyma := &v1beta2.Kyma{Spec: v1beta2.KymaSpec{MaintenanceWindows: []v1beta2.MaintenanceWindow{
+		{Days: []v1beta2.Weekday{"Sunday"}, Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}},
+		{Days: []v1beta2.Weekday{"Wednesday"}, Start: "03:00", Duration: metav1.Duration{Duration: time.Hour}},
+	}}}
+	// Monday, 6th of November 2023
+	now := time.Date(2023, 11, 6, 12, 0, 0, 0, time.UTC)
+
+	open, err := kyma.IsInMaintenanceWindow(now)
+	require.NoError(t, err)
+	assert.False(t, open)
+
+	next, err := kyma.NextMaintenanceWindow(now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 11, 8, 3, 0, 0, 0, time.UTC), next)
+}
+
+func TestKyma_IsInMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	open, err := (&v1beta2.Kyma{}).IsInMaintenanceWindow(time.Now())
+	require.NoError(t, err)
+	assert.True(t, open, "a kyma without maintenance windows is always in maintenance")
+
+	invalid := &v1beta2.Kyma{Spec: v1beta2.KymaSpec{MaintenanceWindows: []v1beta2.MaintenanceWindow{
+		{Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+	}}}
+	_, err = invalid.IsInMaintenanceWindow(time.Now())
+	require.ErrorIs(t, err, v1beta2.ErrInvalidMaintenanceWindow)
+}
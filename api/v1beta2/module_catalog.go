@@ -0,0 +1,35 @@
+package v1beta2
+
+// CatalogModule is a module that can be enabled in a Kyma, as offered by the ModuleTemplates visible to it.
+type CatalogModule struct {
+	// Name is the name of the module, as used in .spec.modules[].name.
+	Name string `json:"name"`
+
+	// FQDN is the fully qualified domain name of the module from the descriptor of its ModuleTemplates.
+	// +optional
+	FQDN string `json:"fqdn,omitempty"`
+
+	// Provider is the provider of the module from the descriptor of its ModuleTemplates.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// Versions lists the versions of the module offered in each channel, ordered by channel.
+	Versions []CatalogVersion `json:"versions"`
+}
+
+// CatalogVersion is a version of a module offered in a channel by a single ModuleTemplate.
+type CatalogVersion struct {
+	// Channel the version is offered in.
+	Channel string `json:"channel"`
+
+	// Version of the module from the descriptor of the ModuleTemplate.
+	Version string `json:"version"`
+
+	// Beta is true if the ModuleTemplate is only visible to Kymas with the beta label.
+	// +optional
+	Beta bool `json:"beta,omitempty"`
+
+	// Internal is true if the ModuleTemplate is only visible to Kymas with the internal label.
+	// +optional
+	Internal bool `json:"internal,omitempty"`
+}
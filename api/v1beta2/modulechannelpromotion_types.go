@@ -0,0 +1,118 @@
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModuleChannelPromotionSpec defines from which channel to which channel the ModuleTemplate of a module is promoted.
+type ModuleChannelPromotionSpec struct {
+	// Module is the name of the module, as set in the operator.kyma-project.io/module-name label
+	// of its ModuleTemplates in the namespace of the ModuleChannelPromotion.
+	Module string `json:"module"`
+
+	// SourceChannel is the channel whose ModuleTemplate is promoted.
+	// +kubebuilder:validation:Pattern:=^[a-z]+$
+	// +kubebuilder:validation:MaxLength:=32
+	// +kubebuilder:validation:MinLength:=3
+	SourceChannel string `json:"sourceChannel"`
+
+	// TargetChannel is the channel whose ModuleTemplate receives the descriptor of the SourceChannel.
+	// The ModuleTemplate is created if the module is not yet available in the TargetChannel.
+	// +kubebuilder:validation:Pattern:=^[a-z]+$
+	// +kubebuilder:validation:MaxLength:=32
+	// +kubebuilder:validation:MinLength:=3
+	TargetChannel string `json:"targetChannel"`
+
+	// SoakTime is how long a version has to be available in the SourceChannel before it is promoted.
+	// +kubebuilder:default:="24h"
+	SoakTime metav1.Duration `json:"soakTime,omitempty"`
+
+	// Paused stops the promotion, so that no further version is promoted to the TargetChannel.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Soaking;Promoted;Paused;Blocked;Error;""
+type PromotionState string
+
+const (
+	PromotionStateSoaking  PromotionState = "Soaking"
+	PromotionStatePromoted PromotionState = "Promoted"
+	PromotionStatePaused   PromotionState = "Paused"
+	PromotionStateBlocked  PromotionState = "Blocked"
+	PromotionStateError    PromotionState = "Error"
+)
+
+// ModuleChannelPromotionStatus shows the progress of the promotion of the version in the SourceChannel.
+type ModuleChannelPromotionStatus struct {
+	// State is the state of the promotion of the SourceVersion.
+	State PromotionState `json:"state,omitempty"`
+
+	// Message is a human-readable message indicating details about the State.
+	Message string `json:"message,omitempty"`
+
+	// SourceVersion is the version of the module in the SourceChannel.
+	// +optional
+	SourceVersion string `json:"sourceVersion,omitempty"`
+
+	// SourceObservedSince is when the SourceVersion was first observed in the SourceChannel, as recorded in the
+	// SoakStartAnnotation of its ModuleTemplate. The SoakTime is counted from then on.
+	// +optional
+	SourceObservedSince metav1.Time `json:"sourceObservedSince,omitempty"`
+
+	// PromotedVersion is the version that was last promoted to the TargetChannel.
+	// +optional
+	PromotedVersion string `json:"promotedVersion,omitempty"`
+
+	// History contains the latest promotions, the most recent one first.
+	// +optional
+	History []PromotionRecord `json:"history,omitempty"`
+}
+
+// PromotionRecord describes a single promotion of a version from the SourceChannel to the TargetChannel.
+type PromotionRecord struct {
+	// Version is the promoted version.
+	Version string `json:"version"`
+
+	// SourceTemplate is the name of the ModuleTemplate the descriptor was copied from.
+	SourceTemplate string `json:"sourceTemplate"`
+
+	// TargetTemplate is the name of the ModuleTemplate the descriptor was copied to.
+	TargetTemplate string `json:"targetTemplate"`
+
+	// PromotedAt is the time of the promotion.
+	PromotedAt metav1.Time `json:"promotedAt"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Module",type="string",JSONPath=".spec.module"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourceChannel"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.targetChannel"
+// +kubebuilder:printcolumn:name="Promoted",type="string",JSONPath=".status.promotedVersion"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ModuleChannelPromotion is the Schema for the modulechannelpromotions API.
+// It copies the descriptor of a module from a source channel to a target channel once it soaked long enough.
+type ModuleChannelPromotion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ModuleChannelPromotionSpec   `json:"spec,omitempty"`
+	Status ModuleChannelPromotionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ModuleChannelPromotionList contains a list of ModuleChannelPromotion.
+type ModuleChannelPromotionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModuleChannelPromotion `json:"items"`
+}
+
+//nolint:gochecknoinits
+func init() {
+	SchemeBuilder.Register(&ModuleChannelPromotion{}, &ModuleChannelPromotionList{})
+}
@@ -0,0 +1,104 @@
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModuleRolloutSpec defines how a new generation of a ModuleTemplate is rolled out to the Kymas using it.
+type ModuleRolloutSpec struct {
+	// Template is the name of the ModuleTemplate in the namespace of the ModuleRollout whose generation
+	// changes are rolled out.
+	Template string `json:"template"`
+
+	// MaxKymasPerWindow limits how many Kymas adopt a new generation of the ModuleTemplate within one Window.
+	// +kubebuilder:validation:Minimum:=1
+	MaxKymasPerWindow int `json:"maxKymasPerWindow"`
+
+	// Window is the time window in which at most MaxKymasPerWindow Kymas adopt a new generation.
+	// +kubebuilder:default:="1h"
+	Window metav1.Duration `json:"window,omitempty"`
+
+	// MaxErrors pauses the rollout as soon as more than MaxErrors of the adopting Kymas report the module in Error.
+	// The rollout continues once enough of them recovered.
+	// +kubebuilder:validation:Minimum:=0
+	MaxErrors int `json:"maxErrors"`
+
+	// Paused stops the rollout, so that no further Kyma adopts the new generation.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Progressing;Paused;""
+type RolloutState string
+
+const (
+	RolloutStateProgressing RolloutState = "Progressing"
+	RolloutStatePaused      RolloutState = "Paused"
+)
+
+// ModuleRolloutStatus shows the progress of the rollout of the current generation of the ModuleTemplate.
+type ModuleRolloutStatus struct {
+	// State is either Progressing or Paused.
+	State RolloutState `json:"state,omitempty"`
+
+	// Message is a human-readable message indicating details about the State.
+	Message string `json:"message,omitempty"`
+
+	// TargetGeneration is the generation of the ModuleTemplate that is currently rolled out.
+	TargetGeneration int64 `json:"targetGeneration,omitempty"`
+
+	// AdoptedKymas is the number of Kymas that applied the TargetGeneration, including the Kymas that
+	// installed the module with it.
+	// +optional
+	AdoptedKymas int `json:"adoptedKymas,omitempty"`
+
+	// FailedKymas is the number of adopting Kymas that report the module in Error.
+	// +optional
+	FailedKymas int `json:"failedKymas,omitempty"`
+
+	// CountedAt is when AdoptedKymas and FailedKymas were counted. They are recounted periodically
+	// instead of on every reconciliation of a Kyma.
+	// +optional
+	CountedAt metav1.Time `json:"countedAt,omitempty"`
+
+	// WindowStart is the start of the current rollout window.
+	// +optional
+	WindowStart metav1.Time `json:"windowStart,omitempty"`
+
+	// AdmittedKymas contains the Namespace/Name of the Kymas admitted to adopt the TargetGeneration
+	// in the current window, which are at most MaxKymasPerWindow.
+	// +optional
+	// +listType=set
+	AdmittedKymas []string `json:"admittedKymas,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Template",type="string",JSONPath=".spec.template"
+// +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".status.targetGeneration"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ModuleRollout is the Schema for the modulerollouts API.
+// It limits how fast a new generation of a ModuleTemplate is adopted by the Kymas using it.
+type ModuleRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ModuleRolloutSpec   `json:"spec,omitempty"`
+	Status ModuleRolloutStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ModuleRolloutList contains a list of ModuleRollout.
+type ModuleRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModuleRollout `json:"items"`
+}
+
+//nolint:gochecknoinits
+func init() {
+	SchemeBuilder.Register(&ModuleRollout{}, &ModuleRolloutList{})
+}
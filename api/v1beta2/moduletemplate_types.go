@@ -25,6 +25,7 @@ import (
 	"github.com/kyma-project/lifecycle-manager/api/shared"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -107,14 +108,32 @@ type ModuleTemplateSpec struct {
 	Descriptor runtime.RawExtension `json:"descriptor"`
 
 	CustomStateCheck []*CustomStateCheck `json:"customStateCheck,omitempty"`
+
+	// Dependencies lists the modules that have to be installed and Ready before this module is installed.
+	// An entry can reference a module of the same Kyma by its module name label, its name in the Kyma,
+	// or its FQDN. The module is also deleted before any of its dependencies.
+	// +optional
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// Audience selects the Kymas that can use the ModuleTemplate by their labels, e.g. region, plan
+	// or customer tier. A ModuleTemplate without an Audience is available to every Kyma.
+	// +optional
+	Audience *metav1.LabelSelector `json:"audience,omitempty"`
 }
 
 type CustomStateCheck struct {
 	// JSONPath specifies the JSON path to the state variable in the Module CR
-	JSONPath string `json:"jsonPath" yaml:"jsonPath"`
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty" yaml:"jsonPath,omitempty"`
 
 	// Value is the value at the JSONPath for which the Module CR state should map with MappedState
-	Value string `json:"value" yaml:"value"`
+	// +optional
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// Expression is a CEL expression that evaluates to true if the Module CR state should map with MappedState.
+	// The Module CR is available as self. Either JSONPath or Expression has to be set.
+	// +optional
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
 
 	// MappedState is the Kyma CR State
 	MappedState shared.State `json:"mappedState" yaml:"mappedState"`
@@ -233,3 +252,40 @@ func (m *ModuleTemplate) IsBeta() bool {
 	}
 	return false
 }
+
+// MatchesAudience returns true if the Audience of the ModuleTemplate selects the given labels of a Kyma.
+func (m *ModuleTemplate) MatchesAudience(kymaLabels map[string]string) (bool, error) {
+	if m.Spec.Audience == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(m.Spec.Audience)
+	if err != nil {
+		return false, fmt.Errorf("invalid audience of ModuleTemplate %s: %w", m.Name, err)
+	}
+	return selector.Matches(labels.Set(kymaLabels)), nil
+}
+
+// IsRollbackTo returns true if the ModuleTemplate is annotated to permit a downgrade to the given version.
+func (m *ModuleTemplate) IsRollbackTo(version string) bool {
+	rollbackVersion, found := m.Annotations[RollbackVersionAnnotation]
+	if !found {
+		return false
+	}
+	expected, err := semver.ParseTolerant(rollbackVersion)
+	if err != nil {
+		return false
+	}
+	actual, err := semver.ParseTolerant(version)
+	if err != nil {
+		return false
+	}
+	return expected.Equals(actual)
+}
+
+// GetCriticality returns the criticality annotated on the ModuleTemplate, which defaults to ModuleCritical.
+func (m *ModuleTemplate) GetCriticality() ModuleCriticality {
+	if ModuleCriticality(m.Annotations[CriticalityAnnotation]) == ModuleOptional {
+		return ModuleOptional
+	}
+	return ModuleCritical
+}
@@ -99,3 +99,47 @@ func TestModuleTemplate_GetComponentDescriptorCacheKey(t *testing.T) {
 		})
 	}
 }
+
+func TestModuleTemplate_MatchesAudience(t *testing.T) {
+	t.Parallel()
+
+	kymaLabels := map[string]string{"region": "eu-west", "plan": "enterprise"}
+	tests := []struct {
+		name     string
+		audience *v1.LabelSelector
+		want     bool
+		wantErr  bool
+	}{
+		{"no audience", nil, true, false},
+		{"matching labels", &v1.LabelSelector{MatchLabels: map[string]string{"plan": "enterprise"}}, true, false},
+		{"other labels", &v1.LabelSelector{MatchLabels: map[string]string{"plan": "free"}}, false, false},
+		{
+			"matching expression",
+			&v1.LabelSelector{MatchExpressions: []v1.LabelSelectorRequirement{
+				{Key: "region", Operator: v1.LabelSelectorOpIn, Values: []string{"eu-west", "eu-central"}},
+			}},
+			true, false,
+		},
+		{
+			"invalid expression",
+			&v1.LabelSelector{MatchExpressions: []v1.LabelSelectorRequirement{
+				{Key: "region", Operator: "Near"},
+			}},
+			false, true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			m := &v1beta2.ModuleTemplate{Spec: v1beta2.ModuleTemplateSpec{Audience: testCase.audience}}
+			got, err := m.MatchesAudience(kymaLabels)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("MatchesAudience() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if got != testCase.want {
+				t.Errorf("MatchesAudience() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -50,6 +51,9 @@ var _ webhook.Validator = &ModuleTemplate{}
 func (m *ModuleTemplate) ValidateCreate() (admission.Warnings, error) {
 	logf.Log.WithName("moduletemplate-resource").
 		Info("validate create", "name", m.Name)
+	if err := m.validateSpec(); err != nil {
+		return nil, err
+	}
 	newDescriptor, err := m.GetDescriptor()
 	if err != nil {
 		return nil, err
@@ -61,6 +65,9 @@ func (m *ModuleTemplate) ValidateCreate() (admission.Warnings, error) {
 func (m *ModuleTemplate) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	logf.Log.WithName("moduletemplate-resource").
 		Info("validate update", "name", m.Name)
+	if err := m.validateSpec(); err != nil {
+		return nil, err
+	}
 	newDescriptor, err := m.GetDescriptor()
 	if err != nil {
 		return nil, err
@@ -73,6 +80,11 @@ func (m *ModuleTemplate) ValidateUpdate(old runtime.Object) (admission.Warnings,
 	if err != nil {
 		return nil, err
 	}
+	if m.IsRollbackTo(newDescriptor.Version) {
+		logf.Log.WithName("moduletemplate-resource").
+			Info("validate rollback", "name", m.Name, "from", oldDescriptor.Version, "to", newDescriptor.Version)
+		return nil, Validate(nil, newDescriptor, m.Name)
+	}
 	return nil, Validate(oldDescriptor, newDescriptor, m.Name)
 }
 
@@ -106,6 +118,22 @@ func Validate(oldDescriptor, newDescriptor *Descriptor, newTemplateName string)
 	return nil
 }
 
+// validateSpec validates the fields of the spec that are evaluated for every Kyma, so that a broken
+// ModuleTemplate is rejected instead of failing the installation of the module.
+func (m *ModuleTemplate) validateSpec() error {
+	specPath := field.NewPath("spec")
+	errs := ValidateCustomStateChecks(m.Spec.CustomStateCheck, specPath.Child("customStateCheck"))
+	if m.Spec.Audience != nil {
+		if _, err := metav1.LabelSelectorAsSelector(m.Spec.Audience); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("audience"), m.Spec.Audience, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "ModuleTemplate"}, m.Name, errs)
+	}
+	return nil
+}
+
 func validationErr(newTemplateName string, newVersion string, errMsg string) *apierrors.StatusError {
 	return apierrors.NewInvalid(
 		schema.GroupKind{Group: GroupVersion.Group, Kind: "ModuleTemplate"},
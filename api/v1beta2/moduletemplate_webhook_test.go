@@ -1,10 +1,14 @@
 package v1beta2_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func Test_ValidateVersion(t *testing.T) {
@@ -58,3 +62,59 @@ func Test_ValidateVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateCustomStateChecks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		stateCheck v1beta2.CustomStateCheck
+		isValid    bool
+	}{
+		{
+			name:       "valid JSONPath check",
+			stateCheck: v1beta2.CustomStateCheck{JSONPath: "status.health", Value: "green"},
+			isValid:    true,
+		},
+		{
+			name: "valid expression",
+			stateCheck: v1beta2.CustomStateCheck{
+				Expression: `self.status.conditions.exists(c, c.type == "Installed" && c.status == "True")`,
+			},
+			isValid: true,
+		},
+		{
+			name:       "expression and JSONPath",
+			stateCheck: v1beta2.CustomStateCheck{JSONPath: "status.health", Expression: "true"},
+			isValid:    false,
+		},
+		{
+			name:       "neither expression nor JSONPath",
+			stateCheck: v1beta2.CustomStateCheck{Value: "green"},
+			isValid:    false,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := v1beta2.ValidateCustomStateChecks([]*v1beta2.CustomStateCheck{&testCase.stateCheck},
+				field.NewPath("spec").Child("customStateCheck"))
+			if got := len(errs) == 0; got != testCase.isValid {
+				t.Errorf("ValidateCustomStateChecks() = %v, isValid %v", errs, testCase.isValid)
+			}
+		})
+	}
+}
+
+func Test_ValidateCreate_InvalidAudience(t *testing.T) {
+	t.Parallel()
+	template := &v1beta2.ModuleTemplate{Spec: v1beta2.ModuleTemplateSpec{
+		Audience: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "region", Operator: "Unknown", Values: []string{"eu-west"}},
+		}},
+	}}
+	_, err := template.ValidateCreate()
+	if !apierrors.IsInvalid(err) || !strings.Contains(err.Error(), "spec.audience") {
+		t.Errorf("ValidateCreate() error = %v, want invalid spec.audience", err)
+	}
+}
@@ -12,4 +12,23 @@ const (
 	IsClusterScopedAnnotation  = OperatorPrefix + Separator + "is-cluster-scoped"
 	CustomStateCheckAnnotation = OperatorPrefix + Separator + "custom-state-check"
 	ModuleVersionAnnotation    = OperatorPrefix + "module-version"
+	// DependenciesAnnotation lists the modules of the Kyma a Manifest depends on, separated by commas.
+	// It is used to delete the Manifests in reverse dependency order.
+	DependenciesAnnotation = OperatorPrefix + Separator + "dependencies"
+	// RollbackVersionAnnotation permits a ModuleTemplate to downgrade the module to the given version.
+	// It has to match the version of the descriptor in the ModuleTemplate.
+	RollbackVersionAnnotation = OperatorPrefix + Separator + "rollback-version"
+	// CriticalityAnnotation sets the default ModuleCriticality of the module of a ModuleTemplate.
+	CriticalityAnnotation = OperatorPrefix + Separator + "criticality"
+	// ChannelSunsetAnnotation deprecates the channel of a ModuleTemplate. Its value is the date or RFC3339
+	// timestamp at which the channel reaches its end of life.
+	ChannelSunsetAnnotation = OperatorPrefix + Separator + "channel-sunset"
+	// ChannelSuccessorAnnotation names the channel that modules are migrated to once the channel of a
+	// ModuleTemplate deprecated with ChannelSunsetAnnotation reached its end of life.
+	ChannelSuccessorAnnotation = OperatorPrefix + Separator + "channel-successor"
+	// SoakStartAnnotation records on a ModuleTemplate since when the version of its descriptor is available
+	// in its channel, as "<version>@<RFC3339 timestamp>". A ModuleChannelPromotion counts the SoakTime from then on.
+	SoakStartAnnotation = OperatorPrefix + Separator + "soak-start"
+	// TargetNamespaceAnnotation is the Module.TargetNamespace of the module of a Manifest.
+	TargetNamespaceAnnotation = OperatorPrefix + Separator + "target-namespace"
 )
@@ -38,6 +38,9 @@ const (
 	// If put on the Kyma object, allows to disable sync for all ModuleTemplatesByLabel
 	// If put on a single ModuleTemplate, allows to disable sync just for this object.
 	SyncLabel = OperatorPrefix + Separator + "sync"
+
+	// PausedLabel is put on the Manifest of a paused module, so that its resources are neither applied nor pruned.
+	PausedLabel = OperatorPrefix + Separator + "paused"
 )
 
 func (kyma *Kyma) EnsureLabelsAndFinalizers() bool {
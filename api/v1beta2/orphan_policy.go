@@ -0,0 +1,59 @@
+package v1beta2
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OrphanAction determines what happens to an installed Module whose ModuleTemplate was deleted.
+// +kubebuilder:validation:Enum=Freeze;Uninstall;Fail
+type OrphanAction string
+
+const (
+	// OrphanActionFreeze keeps the Module running as it is and reports it in Warning.
+	OrphanActionFreeze OrphanAction = "Freeze"
+	// OrphanActionUninstall deletes the Manifest of the Module once the GracePeriod passed.
+	OrphanActionUninstall OrphanAction = "Uninstall"
+	// OrphanActionFail keeps the Module running as it is and reports it in Error.
+	OrphanActionFail OrphanAction = "Fail"
+)
+
+var ErrInvalidOrphanAction = errors.New("invalid orphan action")
+
+// ParseOrphanAction parses the given action, so that a misspelled default action fails at startup
+// instead of silently failing orphaned modules.
+func ParseOrphanAction(action string) (OrphanAction, error) {
+	switch parsed := OrphanAction(action); parsed {
+	case OrphanActionFreeze, OrphanActionUninstall, OrphanActionFail:
+		return parsed, nil
+	default:
+		return "", fmt.Errorf("%w %q: must be one of %s, %s or %s", ErrInvalidOrphanAction, action,
+			OrphanActionFreeze, OrphanActionUninstall, OrphanActionFail)
+	}
+}
+
+// DefaultOrphanGracePeriod is the GracePeriod of an OrphanPolicy that does not set one.
+const DefaultOrphanGracePeriod = 24 * time.Hour
+
+// OrphanPolicy determines what happens to the installed Modules of a Kyma whose ModuleTemplate was deleted.
+type OrphanPolicy struct {
+	// Action is applied to the orphaned Modules. The Manifest of an orphaned Module is never updated.
+	Action OrphanAction `json:"action"`
+
+	// GracePeriod is how long an orphaned Module keeps running before it is uninstalled by the
+	// Uninstall Action. Defaults to 24h.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// UninstallAt returns when a Module orphaned at the given time is uninstalled by the Uninstall Action.
+func (p *OrphanPolicy) UninstallAt(orphanedSince time.Time) time.Time {
+	gracePeriod := DefaultOrphanGracePeriod
+	if p.GracePeriod != nil {
+		gracePeriod = p.GracePeriod.Duration
+	}
+	return orphanedSince.Add(gracePeriod)
+}
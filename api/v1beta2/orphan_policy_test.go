@@ -0,0 +1,41 @@
+package v1beta2_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+func TestParseOrphanAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		action  string
+		want    v1beta2.OrphanAction
+		wantErr bool
+	}{
+		{action: "Freeze", want: v1beta2.OrphanActionFreeze},
+		{action: "Uninstall", want: v1beta2.OrphanActionUninstall},
+		{action: "Fail", want: v1beta2.OrphanActionFail},
+		{action: "uninstall", wantErr: true},
+		{action: "", wantErr: true},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.action, func(t *testing.T) {
+			t.Parallel()
+
+			action, err := v1beta2.ParseOrphanAction(testCase.action)
+
+			if testCase.wantErr {
+				require.ErrorIs(t, err, v1beta2.ErrInvalidOrphanAction)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, action)
+		})
+	}
+}
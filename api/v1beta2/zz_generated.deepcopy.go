@@ -25,6 +25,41 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogModule) DeepCopyInto(out *CatalogModule) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]CatalogVersion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogModule.
+func (in *CatalogModule) DeepCopy() *CatalogModule {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogVersion) DeepCopyInto(out *CatalogVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogVersion.
+func (in *CatalogVersion) DeepCopy() *CatalogVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomStateCheck) DeepCopyInto(out *CustomStateCheck) {
 	*out = *in
@@ -157,7 +192,21 @@ func (in *KymaSpec) DeepCopyInto(out *KymaSpec) {
 	if in.Modules != nil {
 		in, out := &in.Modules, &out.Modules
 		*out = make([]Module, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrphanPolicy != nil {
+		in, out := &in.OrphanPolicy, &out.OrphanPolicy
+		*out = new(OrphanPolicy)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -188,6 +237,13 @@ func (in *KymaStatus) DeepCopyInto(out *KymaStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = make([]CatalogModule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.LastOperation.DeepCopyInto(&out.LastOperation)
 }
 
@@ -201,6 +257,27 @@ func (in *KymaStatus) DeepCopy() *KymaStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Manifest) DeepCopyInto(out *Manifest) {
 	*out = *in
@@ -288,6 +365,11 @@ func (in *ManifestSpec) DeepCopy() *ManifestSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Module) DeepCopyInto(out *Module) {
 	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(ModuleConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Module.
@@ -300,6 +382,241 @@ func (in *Module) DeepCopy() *Module {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleChannelPromotion) DeepCopyInto(out *ModuleChannelPromotion) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleChannelPromotion.
+func (in *ModuleChannelPromotion) DeepCopy() *ModuleChannelPromotion {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleChannelPromotion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModuleChannelPromotion) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleChannelPromotionList) DeepCopyInto(out *ModuleChannelPromotionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModuleChannelPromotion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleChannelPromotionList.
+func (in *ModuleChannelPromotionList) DeepCopy() *ModuleChannelPromotionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleChannelPromotionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModuleChannelPromotionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleChannelPromotionSpec) DeepCopyInto(out *ModuleChannelPromotionSpec) {
+	*out = *in
+	out.SoakTime = in.SoakTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleChannelPromotionSpec.
+func (in *ModuleChannelPromotionSpec) DeepCopy() *ModuleChannelPromotionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleChannelPromotionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleChannelPromotionStatus) DeepCopyInto(out *ModuleChannelPromotionStatus) {
+	*out = *in
+	in.SourceObservedSince.DeepCopyInto(&out.SourceObservedSince)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]PromotionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleChannelPromotionStatus.
+func (in *ModuleChannelPromotionStatus) DeepCopy() *ModuleChannelPromotionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleChannelPromotionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleConfig) DeepCopyInto(out *ModuleConfig) {
+	*out = *in
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ModuleConfigKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleConfig.
+func (in *ModuleConfig) DeepCopy() *ModuleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleConfigKeyRef) DeepCopyInto(out *ModuleConfigKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleConfigKeyRef.
+func (in *ModuleConfigKeyRef) DeepCopy() *ModuleConfigKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleConfigKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleRollout) DeepCopyInto(out *ModuleRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleRollout.
+func (in *ModuleRollout) DeepCopy() *ModuleRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModuleRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleRolloutList) DeepCopyInto(out *ModuleRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModuleRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleRolloutList.
+func (in *ModuleRolloutList) DeepCopy() *ModuleRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModuleRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleRolloutSpec) DeepCopyInto(out *ModuleRolloutSpec) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleRolloutSpec.
+func (in *ModuleRolloutSpec) DeepCopy() *ModuleRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleRolloutStatus) DeepCopyInto(out *ModuleRolloutStatus) {
+	*out = *in
+	in.CountedAt.DeepCopyInto(&out.CountedAt)
+	in.WindowStart.DeepCopyInto(&out.WindowStart)
+	if in.AdmittedKymas != nil {
+		in, out := &in.AdmittedKymas, &out.AdmittedKymas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleRolloutStatus.
+func (in *ModuleRolloutStatus) DeepCopy() *ModuleRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModuleStatus) DeepCopyInto(out *ModuleStatus) {
 	*out = *in
@@ -318,6 +635,17 @@ func (in *ModuleStatus) DeepCopyInto(out *ModuleStatus) {
 		*out = new(TrackingObject)
 		**out = **in
 	}
+	if in.OrphanedSince != nil {
+		in, out := &in.OrphanedSince, &out.OrphanedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ModuleTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleStatus.
@@ -407,6 +735,16 @@ func (in *ModuleTemplateSpec) DeepCopyInto(out *ModuleTemplateSpec) {
 			}
 		}
 	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleTemplateSpec.
@@ -419,6 +757,42 @@ func (in *ModuleTemplateSpec) DeepCopy() *ModuleTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleTransition) DeepCopyInto(out *ModuleTransition) {
+	*out = *in
+	in.TransitionTime.DeepCopyInto(&out.TransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleTransition.
+func (in *ModuleTransition) DeepCopy() *ModuleTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanPolicy) DeepCopyInto(out *OrphanPolicy) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanPolicy.
+func (in *OrphanPolicy) DeepCopy() *OrphanPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PartialMeta) DeepCopyInto(out *PartialMeta) {
 	*out = *in
@@ -434,6 +808,22 @@ func (in *PartialMeta) DeepCopy() *PartialMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionRecord) DeepCopyInto(out *PromotionRecord) {
+	*out = *in
+	in.PromotedAt.DeepCopyInto(&out.PromotedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionRecord.
+func (in *PromotionRecord) DeepCopy() *PromotionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -4,7 +4,9 @@ import (
 	"flag"
 	"time"
 
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
 	"github.com/kyma-project/lifecycle-manager/internal/controller"
+	"github.com/kyma-project/lifecycle-manager/internal/manifest"
 
 	"github.com/kyma-project/lifecycle-manager/pkg/log"
 )
@@ -31,6 +33,8 @@ const (
 	defaultIstioGatewayName                = "lifecycle-manager-watcher-gateway"
 	defaultIstioGatewayNamespace           = "kcp-system"
 	defaultIstioNamespace                  = "istio-system"
+	defaultModuleSourceCacheTTL            = 5 * time.Minute
+	defaultPruneProtectedKinds             = "PersistentVolumeClaim"
 )
 
 //nolint:funlen
@@ -46,6 +50,8 @@ func defineFlagVar() *FlagVar {
 		"The address the skr listener endpoint binds to.")
 	flag.StringVar(&flagVar.pprofAddr, "pprof-bind-address", ":8084",
 		"The address the pprof endpoint binds to.")
+	flag.StringVar(&flagVar.kymaPlanAddr, "kyma-plan-bind-address", "",
+		"The address the endpoint to plan Kyma changes binds to. The endpoint is disabled if empty.")
 	flag.IntVar(&flagVar.maxConcurrentKymaReconciles, "max-concurrent-kyma-reconciles",
 		defaultMaxConcurrentKymaReconciles, "The maximum number of concurrent Kyma Reconciles which can be run.")
 	flag.IntVar(&flagVar.maxConcurrentManifestReconciles, "max-concurrent-manifest-reconciles",
@@ -128,6 +134,41 @@ func defineFlagVar() *FlagVar {
 	flag.StringVar(&flagVar.remoteSyncNamespace, "sync-namespace", controller.DefaultRemoteSyncNamespace,
 		"Name of the namespace for syncing remote Kyma and module catalog")
 	flag.BoolVar(&flagVar.isKymaManaged, "is-kyma-managed", false, "indicates whether Kyma is managed")
+	flag.StringVar(&flagVar.moduleCRDriftDetection, "module-cr-drift-detection",
+		string(manifest.DriftDetectionDisabled), "Periodically compares module CRs with their Manifest. "+
+			"One of disabled, report (reports drifted fields in the Manifest) or "+
+			"correct (additionally re-applies the drifted fields).")
+	flag.StringVar(&flagVar.channelAliases, "channel-aliases", "",
+		"Aliases of channels for the lookup of ModuleTemplates. Example: 'stable=regular,edge=fast'.")
+	flag.StringVar(&flagVar.channelFallbacks, "channel-fallbacks", "",
+		"Channels that are looked up in order if a channel offers no ModuleTemplate for a module. "+
+			"Example: 'experimental->fast->regular'.")
+	flag.StringVar(&flagVar.channelDeprecations, "channel-deprecations", "",
+		"Deprecated channels with their sunset date and the channel modules are migrated to afterwards. "+
+			"Example: 'alpha=2024-06-30->regular'.")
+	flag.StringVar(&flagVar.moduleOrphanAction, "module-orphan-action", string(v1beta2.OrphanActionFail),
+		"Default action for installed modules whose ModuleTemplate was deleted, if a Kyma sets no orphan policy. "+
+			"One of Freeze, Uninstall (after the grace period) or Fail.")
+	flag.DurationVar(&flagVar.moduleOrphanGracePeriod, "module-orphan-grace-period", v1beta2.DefaultOrphanGracePeriod,
+		"Default grace period after which orphaned modules are uninstalled by the Uninstall action.")
+	flag.StringVar(&flagVar.moduleSourceDir, "module-source-dir", "",
+		"Directory with files of ModuleTemplates that modules are resolved from besides the ModuleTemplates "+
+			"in the cluster.")
+	flag.StringVar(&flagVar.moduleSourceOCMRepository, "module-source-ocm-repository", "",
+		"OCM component repository that modules are resolved from besides the ModuleTemplates in the cluster. "+
+			"Example: 'europe-docker.pkg.dev/kyma-project/prod'.")
+	flag.StringVar(&flagVar.moduleSourceOCMComponents, "module-source-ocm-components", "",
+		"Modules resolved from the OCM component repository with the names of their components. "+
+			"Example: 'btp-operator=kyma-project.io/module/btp-operator'.")
+	flag.DurationVar(&flagVar.moduleSourceCacheTTL, "module-source-cache-ttl", defaultModuleSourceCacheTTL,
+		"Duration for which the modules resolved from the module source directory and "+
+			"the OCM component repository are cached.")
+	flag.StringVar(&flagVar.pruneProtectionSelector, "prune-protection-selector", "",
+		"Label selector of module resources that are orphaned instead of deleted when they are dropped from "+
+			"a module manifest. Example: 'operator.kyma-project.io/protected=true'.")
+	flag.StringVar(&flagVar.pruneProtectedKinds, "prune-protected-kinds", defaultPruneProtectedKinds,
+		"Kinds of module resources that are orphaned instead of deleted when they are dropped from "+
+			"a module manifest. Example: 'PersistentVolumeClaim,Certificate.cert-manager.io'.")
 	return flagVar
 }
 
@@ -163,6 +204,7 @@ type FlagVar struct {
 	skrWatcherImage                        string
 	pprof                                  bool
 	pprofAddr                              string
+	kymaPlanAddr                           string
 	pprofServerTimeout                     time.Duration
 	failureBaseDelay, failureMaxDelay      time.Duration
 	rateLimiterBurst, rateLimiterFrequency int
@@ -176,4 +218,16 @@ type FlagVar struct {
 	remoteSyncNamespace                    string
 	enableVerification                     bool
 	isKymaManaged                          bool
+	moduleCRDriftDetection                 string
+	channelAliases                         string
+	channelFallbacks                       string
+	channelDeprecations                    string
+	moduleOrphanAction                     string
+	moduleOrphanGracePeriod                time.Duration
+	moduleSourceDir                        string
+	moduleSourceOCMRepository              string
+	moduleSourceOCMComponents              string
+	moduleSourceCacheTTL                   time.Duration
+	pruneProtectionSelector                string
+	pruneProtectedKinds                    string
 }
@@ -30,7 +30,8 @@ import (
 
 	"github.com/kyma-project/lifecycle-manager/internal/controller"
 
-	_ "github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ocireg"
 
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/time/rate"
@@ -57,8 +58,13 @@ import (
 	"github.com/kyma-project/lifecycle-manager/internal"
 	"github.com/kyma-project/lifecycle-manager/internal/controller/kyma/metrics"
 	purgemetrics "github.com/kyma-project/lifecycle-manager/internal/controller/purge/metrics"
+	declarative "github.com/kyma-project/lifecycle-manager/internal/declarative/v2"
+	"github.com/kyma-project/lifecycle-manager/internal/manifest"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
 	"github.com/kyma-project/lifecycle-manager/pkg/log"
 	"github.com/kyma-project/lifecycle-manager/pkg/matcher"
+	moduleconfig "github.com/kyma-project/lifecycle-manager/pkg/module/config"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/promotion"
 	"github.com/kyma-project/lifecycle-manager/pkg/queue"
 	"github.com/kyma-project/lifecycle-manager/pkg/remote"
 	"github.com/kyma-project/lifecycle-manager/pkg/signature"
@@ -143,8 +149,35 @@ func setupManager(flagVar *FlagVar, newCacheOptions cache.Options, scheme *runti
 
 	remoteClientCache := remote.NewClientCache()
 
-	setupKymaReconciler(mgr, remoteClientCache, flagVar, options)
+	channelResolver, err := channel.ParseResolver(flagVar.channelAliases, flagVar.channelFallbacks)
+	if err != nil {
+		setupLog.Error(err, "unable to parse channel aliases and fallbacks")
+		os.Exit(1)
+	}
+	channelDeprecations, err := channel.ParseDeprecations(flagVar.channelDeprecations)
+	if err != nil {
+		setupLog.Error(err, "unable to parse channel deprecations")
+		os.Exit(1)
+	}
+	if err := channel.IndexModuleTemplates(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to index module templates")
+		os.Exit(1)
+	}
+	moduleSources, err := moduleSourcesFromFlagVar(flagVar)
+	if err != nil {
+		setupLog.Error(err, "unable to set up module sources")
+		os.Exit(1)
+	}
+	lookupOptions := channel.LookupOptions{
+		Resolver:     channelResolver,
+		Indexed:      true,
+		Deprecations: channelDeprecations,
+		Sources:      moduleSources,
+	}
+
+	setupKymaReconciler(mgr, remoteClientCache, flagVar, options, lookupOptions)
 	setupManifestReconciler(mgr, flagVar, options)
+	setupModuleChannelPromotionReconciler(mgr, flagVar, options)
 
 	if flagVar.enablePurgeFinalizer {
 		setupPurgeReconciler(mgr, remoteClientCache, flagVar, options)
@@ -154,7 +187,7 @@ func setupManager(flagVar *FlagVar, newCacheOptions cache.Options, scheme *runti
 		setupKcpWatcherReconciler(mgr, options, flagVar)
 	}
 	if flagVar.enableWebhooks {
-		enableWebhooks(mgr)
+		enableWebhooks(mgr, lookupOptions)
 	}
 
 	//+kubebuilder:scaffold:builder
@@ -177,9 +210,31 @@ func setupManager(flagVar *FlagVar, newCacheOptions cache.Options, scheme *runti
 	}
 }
 
-func enableWebhooks(mgr manager.Manager) {
-	if err := (&operatorv1beta2.ModuleTemplate{}).
-		SetupWebhookWithManager(mgr); err != nil {
+// moduleSourcesFromFlagVar creates the sources that offer modules besides the ModuleTemplates in the cluster.
+func moduleSourcesFromFlagVar(flagVar *FlagVar) ([]channel.ModuleSource, error) {
+	var sources []channel.ModuleSource
+	if flagVar.moduleSourceDir != "" {
+		sources = append(sources, channel.NewCachedSource(
+			channel.NewFileSource(flagVar.moduleSourceDir), flagVar.moduleSourceCacheTTL))
+	}
+	if flagVar.moduleSourceOCMRepository != "" {
+		components, err := channel.ParseOCMComponents(flagVar.moduleSourceOCMComponents)
+		if err != nil {
+			return nil, err
+		}
+		repository, err := ocm.DefaultContext().RepositoryForSpec(
+			ocireg.NewRepositorySpec(flagVar.moduleSourceOCMRepository))
+		if err != nil {
+			return nil, fmt.Errorf("could not open OCM repository %s: %w", flagVar.moduleSourceOCMRepository, err)
+		}
+		sources = append(sources, channel.NewCachedSource(
+			channel.NewOCMSource(repository, components), flagVar.moduleSourceCacheTTL))
+	}
+	return sources, nil
+}
+
+func enableWebhooks(mgr manager.Manager, lookupOptions channel.LookupOptions) {
+	if err := (manifest.ModuleTemplateValidator{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ModuleTemplate")
 		os.Exit(1)
 	}
@@ -188,6 +243,10 @@ func enableWebhooks(mgr manager.Manager) {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Kyma")
 		os.Exit(1)
 	}
+	if err := moduleconfig.NewValidator(mgr.GetClient(), lookupOptions).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ModuleConfig")
+		os.Exit(1)
+	}
 	if err := (&operatorv1beta2.Watcher{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Watcher")
 		os.Exit(1)
@@ -215,6 +274,7 @@ func controllerOptionsFromFlagVar(flagVar *FlagVar) controllerRuntime.Options {
 func setupKymaReconciler(mgr ctrl.Manager,
 	remoteClientCache *remote.ClientCache,
 	flagVar *FlagVar, options controllerRuntime.Options,
+	lookupOptions channel.LookupOptions,
 ) {
 	options.MaxConcurrentReconciles = flagVar.maxConcurrentKymaReconciles
 	kcpRestConfig := mgr.GetConfig()
@@ -232,6 +292,12 @@ func setupKymaReconciler(mgr ctrl.Manager,
 		}
 	}
 
+	orphanAction, err := operatorv1beta2.ParseOrphanAction(flagVar.moduleOrphanAction)
+	if err != nil {
+		setupLog.Error(err, "unable to parse module orphan action")
+		os.Exit(1)
+	}
+
 	if err := (&controller.KymaReconciler{
 		Client:            mgr.GetClient(),
 		EventRecorder:     mgr.GetEventRecorderFor(operatorv1beta2.OperatorName),
@@ -250,11 +316,17 @@ func setupKymaReconciler(mgr ctrl.Manager,
 		InKCPMode:           flagVar.inKCPMode,
 		RemoteSyncNamespace: flagVar.remoteSyncNamespace,
 		IsManagedKyma:       flagVar.isKymaManaged,
+		TemplateLookup:      lookupOptions,
+		OrphanPolicy: operatorv1beta2.OrphanPolicy{
+			Action:      orphanAction,
+			GracePeriod: &v1.Duration{Duration: flagVar.moduleOrphanGracePeriod},
+		},
 	}).SetupWithManager(
 		mgr, options, controller.SetupUpSetting{
 			ListenerAddr:                 flagVar.kymaListenerAddr,
 			EnableDomainNameVerification: flagVar.enableDomainNameVerification,
 			IstioNamespace:               flagVar.istioNamespace,
+			PlanAddr:                     flagVar.kymaPlanAddr,
 		},
 	); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Kyma")
@@ -305,6 +377,20 @@ func setupPurgeReconciler(mgr ctrl.Manager,
 	purgemetrics.Initialize()
 }
 
+func setupModuleChannelPromotionReconciler(mgr ctrl.Manager, flagVar *FlagVar, options controllerRuntime.Options) {
+	if err := (&controller.ModuleChannelPromotionReconciler{
+		Client:        mgr.GetClient(),
+		EventRecorder: mgr.GetEventRecorderFor(operatorv1beta2.OperatorName),
+		Promoter: promotion.NewPromoter(mgr.GetClient(), signature.VerificationSettings{
+			EnableVerification: flagVar.enableVerification,
+			PublicKeyFilePath:  flagVar.moduleVerificationKeyFilePath,
+		}),
+	}).SetupWithManager(mgr, options); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ModuleChannelPromotion")
+		os.Exit(1)
+	}
+}
+
 func setupManifestReconciler(
 	mgr ctrl.Manager,
 	flagVar *FlagVar,
@@ -314,10 +400,24 @@ func setupManifestReconciler(
 	options.RateLimiter = internal.ManifestRateLimiter(flagVar.failureBaseDelay,
 		flagVar.failureMaxDelay, flagVar.rateLimiterFrequency, flagVar.rateLimiterBurst)
 
+	pruneProtection, err := declarative.ParsePruneProtection(flagVar.pruneProtectionSelector,
+		flagVar.pruneProtectedKinds)
+	if err != nil {
+		setupLog.Error(err, "unable to parse prune protection")
+		os.Exit(1)
+	}
+	driftDetection, err := manifest.ParseDriftDetectionMode(flagVar.moduleCRDriftDetection)
+	if err != nil {
+		setupLog.Error(err, "unable to parse module CR drift detection")
+		os.Exit(1)
+	}
+
 	if err := controller.SetupWithManager(
 		mgr, options, flagVar.manifestRequeueSuccessInterval, controller.SetupUpSetting{
 			ListenerAddr:                 flagVar.manifestListenerAddr,
 			EnableDomainNameVerification: flagVar.enableDomainNameVerification,
+			DriftDetection:               driftDetection,
+			PruneProtection:              pruneProtection,
 		},
 	); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Manifest")
@@ -14,13 +14,14 @@ import (
 )
 
 const (
-	metricKymaState   = "lifecycle_mgr_kyma_state"
-	metricModuleState = "lifecycle_mgr_module_state"
-	kymaNameLabel     = "kyma_name"
-	stateLabel        = "state"
-	shootIDLabel      = "shoot"
-	instanceIDLabel   = "instance_id"
-	moduleNameLabel   = "module_name"
+	metricKymaState     = "lifecycle_mgr_kyma_state"
+	metricModuleState   = "lifecycle_mgr_module_state"
+	metricPausedModules = "lifecycle_mgr_paused_modules"
+	kymaNameLabel       = "kyma_name"
+	stateLabel          = "state"
+	shootIDLabel        = "shoot"
+	instanceIDLabel     = "instance_id"
+	moduleNameLabel     = "module_name"
 )
 
 var (
@@ -32,17 +33,23 @@ var (
 		Name: metricModuleState,
 		Help: "Indicates the Status.state for modules of Kyma",
 	}, []string{moduleNameLabel, kymaNameLabel, stateLabel, shootIDLabel, instanceIDLabel})
+	pausedModulesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{ //nolint:gochecknoglobals
+		Name: metricPausedModules,
+		Help: "Indicates the number of paused modules of a given Kyma object",
+	}, []string{kymaNameLabel, shootIDLabel, instanceIDLabel})
 )
 
 func Initialize() {
 	ctrlMetrics.Registry.MustRegister(kymaStateGauge)
 	ctrlMetrics.Registry.MustRegister(moduleStateGauge)
+	ctrlMetrics.Registry.MustRegister(pausedModulesGauge)
 	listenerMetrics.Init(ctrlMetrics.Registry)
 }
 
 var errMetric = errors.New("failed to update metrics")
 
-// UpdateAll sets both metrics 'lifecycle_mgr_kyma_state' and 'lifecycle_mgr_module_state' to new states.
+// UpdateAll sets the metrics 'lifecycle_mgr_kyma_state' and 'lifecycle_mgr_module_state' to new states
+// and 'lifecycle_mgr_paused_modules' to the number of paused modules.
 func UpdateAll(kyma *v1beta2.Kyma) error {
 	shootID, err := metrics.ExtractShootID(kyma)
 	if err != nil {
@@ -57,11 +64,12 @@ func UpdateAll(kyma *v1beta2.Kyma) error {
 	for _, moduleStatus := range kyma.Status.Modules {
 		setModuleStateGauge(moduleStatus.State, moduleStatus.Name, kyma.Name, shootID, instanceID)
 	}
+	setPausedModulesGauge(kyma, shootID, instanceID)
 	return nil
 }
 
 // CleanupMetrics deletes all 'lifecycle_mgr_kyma_state',
-// 'lifecycle_mgr_module_state' metrics for the matching Kyma.
+// 'lifecycle_mgr_module_state', 'lifecycle_mgr_paused_modules' metrics for the matching Kyma.
 func CleanupMetrics(kyma *v1beta2.Kyma) {
 	kymaStateGauge.DeletePartialMatch(prometheus.Labels{
 		kymaNameLabel: kyma.Name,
@@ -69,6 +77,9 @@ func CleanupMetrics(kyma *v1beta2.Kyma) {
 	moduleStateGauge.DeletePartialMatch(prometheus.Labels{
 		kymaNameLabel: kyma.Name,
 	})
+	pausedModulesGauge.DeletePartialMatch(prometheus.Labels{
+		kymaNameLabel: kyma.Name,
+	})
 }
 
 // RemoveModuleStateMetrics deletes all 'lifecycle_mgr_module_state' metrics for the matching module.
@@ -106,6 +117,20 @@ func setModuleStateGauge(newState shared.State, moduleName, kymaName, shootID, i
 	}
 }
 
+func setPausedModulesGauge(kyma *v1beta2.Kyma, shootID, instanceID string) {
+	paused := 0
+	for _, module := range kyma.Spec.Modules {
+		if module.Paused {
+			paused++
+		}
+	}
+	pausedModulesGauge.With(prometheus.Labels{
+		kymaNameLabel:   kyma.Name,
+		shootIDLabel:    shootID,
+		instanceIDLabel: instanceID,
+	}).Set(float64(paused))
+}
+
 func calcStateValue(state, newState shared.State) float64 {
 	if state == newState {
 		return 1
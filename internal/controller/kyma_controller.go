@@ -20,8 +20,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -38,6 +42,7 @@ import (
 	"github.com/kyma-project/lifecycle-manager/pkg/log"
 	"github.com/kyma-project/lifecycle-manager/pkg/module/common"
 	"github.com/kyma-project/lifecycle-manager/pkg/module/parse"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/rollout"
 	modulesync "github.com/kyma-project/lifecycle-manager/pkg/module/sync"
 	"github.com/kyma-project/lifecycle-manager/pkg/queue"
 	"github.com/kyma-project/lifecycle-manager/pkg/remote"
@@ -56,8 +61,13 @@ const (
 	moduleReconciliationError  EventReasonError = "ModuleReconciliationError"
 	syncContextError           EventReasonError = "SyncContextError"
 	deletionError              EventReasonError = "DeletionError"
+	deprecatedChannel          EventReasonError = "DeprecatedChannel"
+	moduleTemplateDeleted      EventReasonError = "ModuleTemplateDeleted"
 	updateStatus               EventReasonInfo  = "StatusUpdate"
 	webhookChartRemoval        EventReasonInfo  = "WebhookChartRemoval"
+	moduleRollback             EventReasonInfo  = "ModuleRollback"
+	moduleChannelMigrated      EventReasonInfo  = "ModuleChannelMigrated"
+	orphanedModuleUninstall    EventReasonInfo  = "OrphanedModuleUninstall"
 	DefaultRemoteSyncNamespace string           = "kyma-system"
 )
 
@@ -72,6 +82,13 @@ type KymaReconciler struct {
 	InKCPMode           bool
 	RemoteSyncNamespace string
 	IsManagedKyma       bool
+	// TemplateLookup configures the lookup of the ModuleTemplates, e.g. channel aliases and fallbacks.
+	TemplateLookup channel.LookupOptions
+	// OrphanPolicy is applied to the Kymas that do not set their own v1beta2.KymaSpec.OrphanPolicy.
+	OrphanPolicy v1beta2.OrphanPolicy
+
+	// moduleCatalog keeps the offers of the ModuleTemplates between reconciliations of all Kymas.
+	moduleCatalog channel.ModuleCatalog
 }
 
 //nolint:lll
@@ -84,6 +101,8 @@ type KymaReconciler struct {
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=operator.kyma-project.io,resources=moduletemplates,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=operator.kyma-project.io,resources=moduletemplates/finalizers,verbs=update
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=modulerollouts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=modulerollouts/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cert-manager.io,resources=issuers,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;create;update;delete;patch
@@ -332,10 +351,12 @@ func (r *KymaReconciler) handleProcessingState(ctx context.Context, kyma *v1beta
 		if err != nil {
 			return fmt.Errorf("could not reconciling manifest: %w", err)
 		}
-		if kyma.AllModulesReady() {
-			kyma.UpdateCondition(v1beta2.ConditionTypeModules, metav1.ConditionTrue)
-		} else {
-			kyma.UpdateCondition(v1beta2.ConditionTypeModules, metav1.ConditionFalse)
+		kyma.UpdateModuleConditions()
+		return nil
+	})
+	errGroup.Go(func() error {
+		if err := r.updateModuleCatalogStatus(ctx, kyma); err != nil {
+			return fmt.Errorf("could not update module catalog status: %w", err)
 		}
 		return nil
 	})
@@ -410,6 +431,16 @@ func (r *KymaReconciler) handleDeletingState(ctx context.Context, kyma *v1beta2.
 		logger.Info("removed remote finalizer")
 	}
 
+	deleted, err := r.deleteManifestsInDependencyOrder(ctx, kyma)
+	if err != nil {
+		r.enqueueWarningEvent(kyma, deletionError, err)
+		return ctrl.Result{}, err
+	}
+	if !deleted {
+		logger.Info("waiting for modules to be deleted in dependency order")
+		return ctrl.Result{RequeueAfter: r.RequeueIntervals.Busy}, nil
+	}
+
 	metrics.CleanupMetrics(kyma)
 
 	controllerutil.RemoveFinalizer(kyma, v1beta2.Finalizer)
@@ -446,6 +477,7 @@ func (r *KymaReconciler) reconcileManifests(ctx context.Context, kyma *v1beta2.K
 	}
 
 	runner.SyncModuleStatus(ctx, kyma, modules)
+	r.recordModuleRollbacks(kyma, modules)
 	// If module get removed from kyma, the module deletion happens here.
 
 	if err := r.DeleteNoLongerExistingModules(ctx, kyma); err != nil {
@@ -454,6 +486,17 @@ func (r *KymaReconciler) reconcileManifests(ctx context.Context, kyma *v1beta2.K
 	return nil
 }
 
+// recordModuleRollbacks emits an event for every module that was downgraded as permitted by its ModuleTemplate.
+func (r *KymaReconciler) recordModuleRollbacks(kyma *v1beta2.Kyma, modules common.Modules) {
+	for _, module := range modules {
+		if module.Template.Err != nil || module.Template.RollbackFrom == "" || module.IsPaused(kyma) {
+			continue
+		}
+		r.enqueueNormalEvent(kyma, moduleRollback, fmt.Sprintf("module %s rolled back from version %s to %s",
+			module.ModuleName, module.Template.RollbackFrom, module.Version))
+	}
+}
+
 func (r *KymaReconciler) syncModuleCatalog(ctx context.Context, kyma *v1beta2.Kyma) error {
 	moduleTemplateList := &v1beta2.ModuleTemplateList{}
 	if err := r.List(ctx, moduleTemplateList, &client.ListOptions{}); err != nil {
@@ -462,7 +505,11 @@ func (r *KymaReconciler) syncModuleCatalog(ctx context.Context, kyma *v1beta2.Ky
 
 	var modulesToSync []v1beta2.ModuleTemplate
 	for _, mt := range moduleTemplateList.Items {
-		if mt.SyncEnabled(kyma.IsBeta(), kyma.IsInternal()) {
+		if !mt.SyncEnabled(kyma.IsBeta(), kyma.IsInternal()) {
+			continue
+		}
+		// ModuleTemplates with an invalid audience are not synchronized, the module status reports the error.
+		if matches, err := mt.MatchesAudience(kyma.GetLabels()); err == nil && matches {
 			modulesToSync = append(modulesToSync, mt)
 		}
 	}
@@ -474,6 +521,36 @@ func (r *KymaReconciler) syncModuleCatalog(ctx context.Context, kyma *v1beta2.Ky
 	return nil
 }
 
+// updateModuleCatalogStatus lists the modules that can be enabled in the Kyma in its status,
+// which is also synchronized to the runtime. The ModuleTemplates are listed from the cache, and their offers
+// are only computed again once they changed.
+// If a module source is unavailable, the modules of the others are listed and the source is reported
+// in the ModuleSources condition.
+func (r *KymaReconciler) updateModuleCatalogStatus(ctx context.Context, kyma *v1beta2.Kyma) error {
+	catalog, err := r.moduleCatalog.For(ctx, r, r.TemplateLookup, kyma)
+	if err != nil && !errors.Is(err, channel.ErrModuleSourceUnavailable) {
+		return fmt.Errorf("could not aggregate module templates for module catalog status: %w", err)
+	}
+	updateModuleSourcesCondition(kyma, len(r.TemplateLookup.Sources) > 0, err)
+	kyma.Status.Catalog = catalog
+	return nil
+}
+
+// updateModuleSourcesCondition sets the ModuleSources condition to false with the unavailable sources
+// and to true if all are available. Without module sources, it is removed.
+func updateModuleSourcesCondition(kyma *v1beta2.Kyma, hasSources bool, sourcesErr error) {
+	if !hasSources {
+		meta.RemoveStatusCondition(&kyma.Status.Conditions, string(v1beta2.ConditionTypeModuleSources))
+		return
+	}
+	if sourcesErr != nil {
+		kyma.UpdateConditionWithDetails(v1beta2.ConditionTypeModuleSources, metav1.ConditionFalse,
+			strings.ReplaceAll(sourcesErr.Error(), "\n", "; "))
+		return
+	}
+	kyma.UpdateCondition(v1beta2.ConditionTypeModuleSources, metav1.ConditionTrue)
+}
+
 func (r *KymaReconciler) updateStatus(ctx context.Context, kyma *v1beta2.Kyma,
 	state shared.State, message string,
 ) error {
@@ -492,16 +569,244 @@ func (r *KymaReconciler) updateStatusWithError(ctx context.Context, kyma *v1beta
 }
 
 func (r *KymaReconciler) GenerateModulesFromTemplate(ctx context.Context, kyma *v1beta2.Kyma) (common.Modules, error) {
-	templates := channel.GetTemplates(ctx, r, kyma, r.SyncKymaEnabled(kyma))
+	templates := channel.GetTemplates(ctx, r, kyma, r.SyncKymaEnabled(kyma), r.TemplateLookup)
 	for _, template := range templates {
 		if template.Err != nil {
 			r.enqueueWarningEvent(kyma, moduleReconciliationError, template.Err)
 		}
 	}
+	r.recordChannelDeprecations(kyma, templates)
+	r.markOrphanedModules(ctx, kyma, templates)
+	r.recordOrphanedModules(kyma, templates)
+	if err := deferUpgradesOutsideMaintenanceWindow(kyma, templates); err != nil {
+		r.enqueueWarningEvent(kyma, moduleReconciliationError, err)
+	}
+	r.checkModuleRollouts(ctx, kyma, templates, rollout.NewGate(r))
+	return r.parseModules(ctx, kyma, templates), nil
+}
+
+// DryRunModulesFromTemplate generates the modules of the Kyma like GenerateModulesFromTemplate,
+// including the skew, maintenance window and rollout checks, but without side effects: it neither updates
+// any ModuleRollout nor records any event. The Kyma is expected to be a copy, as its status conditions may be modified.
+func (r *KymaReconciler) DryRunModulesFromTemplate(ctx context.Context, kyma *v1beta2.Kyma,
+) (common.Modules, error) {
+	templates := channel.GetTemplates(ctx, r, kyma, r.SyncKymaEnabled(kyma), r.TemplateLookup)
+	updateDeprecatedChannelsCondition(kyma, templates)
+	r.markOrphanedModules(ctx, kyma, templates)
+	// an invalid maintenance window already defers the upgrades of the planned modules.
+	_ = deferUpgradesOutsideMaintenanceWindow(kyma, templates)
+	r.checkModuleRollouts(ctx, kyma, templates, rollout.NewDryRunGate(r))
+	return r.parseModules(ctx, kyma, templates), nil
+}
+
+// recordChannelDeprecations updates the DeprecatedChannels condition and emits a warning for every module using
+// a deprecated channel whenever the condition changes, as well as an event for every module migrated to the successor
+// of its deprecated channel.
+func (r *KymaReconciler) recordChannelDeprecations(kyma *v1beta2.Kyma, templates channel.ModuleTemplatesByModuleName) {
+	previousMessage := ""
+	if condition := meta.FindStatusCondition(kyma.Status.Conditions,
+		string(v1beta2.ConditionTypeDeprecatedChannels)); condition != nil {
+		previousMessage = condition.Message
+	}
+	updateDeprecatedChannelsCondition(kyma, templates)
+	condition := meta.FindStatusCondition(kyma.Status.Conditions, string(v1beta2.ConditionTypeDeprecatedChannels))
+	conditionChanged := condition != nil && condition.Message != previousMessage
+
+	moduleStatusMap := kyma.GetModuleStatusMap()
+	for moduleName, template := range templates {
+		if template.Err != nil || template.Deprecation == nil {
+			continue
+		}
+		if !template.IsMigrated() {
+			if conditionChanged {
+				r.enqueueWarningEvent(kyma, deprecatedChannel,
+					fmt.Errorf("%w: module %s: %s", channel.ErrChannelDeprecated, moduleName, template.Deprecation))
+			}
+			continue
+		}
+		if moduleStatus, found := moduleStatusMap[moduleName]; found &&
+			moduleStatus.Channel == template.Deprecation.Channel {
+			r.enqueueNormalEvent(kyma, moduleChannelMigrated, fmt.Sprintf(
+				"module %s migrated from deprecated channel %s to %s",
+				moduleName, template.Deprecation.Channel, template.Spec.Channel))
+		}
+	}
+}
+
+// updateDeprecatedChannelsCondition sets the DeprecatedChannels condition to false with the modules that still use
+// a deprecated channel, and to true once all of them are migrated. A module whose migration would downgrade it
+// is not migrated: it keeps its installed version on the deprecated channel in the Warning state until the successor
+// channel catches up. Without deprecated channels, the condition is removed.
+func updateDeprecatedChannelsCondition(kyma *v1beta2.Kyma, templates channel.ModuleTemplatesByModuleName) {
+	deprecated := false
+	var inUse []string
+	for moduleName, template := range templates {
+		if template.Deprecation == nil {
+			continue
+		}
+		deprecated = true
+		if !template.IsMigrated() || template.Err != nil {
+			inUse = append(inUse, fmt.Sprintf("%s (%s)", moduleName, template.Deprecation.Channel))
+		}
+	}
+	if !deprecated {
+		meta.RemoveStatusCondition(&kyma.Status.Conditions, string(v1beta2.ConditionTypeDeprecatedChannels))
+		return
+	}
+	if len(inUse) == 0 {
+		kyma.UpdateCondition(v1beta2.ConditionTypeDeprecatedChannels, metav1.ConditionTrue)
+		return
+	}
+	slices.Sort(inUse)
+	kyma.UpdateConditionWithDetails(v1beta2.ConditionTypeDeprecatedChannels, metav1.ConditionFalse,
+		strings.Join(inUse, ", "))
+}
+
+func (r *KymaReconciler) parseModules(ctx context.Context, kyma *v1beta2.Kyma,
+	templates channel.ModuleTemplatesByModuleName,
+) common.Modules {
 	parser := parse.NewParser(r.Client, r.InKCPMode,
 		r.RemoteSyncNamespace, r.EnableVerification, r.PublicKeyFilePath)
 
-	return parser.GenerateModulesFromTemplates(ctx, kyma, templates), nil
+	return parser.GenerateModulesFromTemplates(ctx, kyma, templates)
+}
+
+// markOrphanedModules applies the OrphanPolicy of the Kyma to the installed modules whose ModuleTemplate was deleted.
+// A module is only orphaned if the ModuleTemplate tracked in its status no longer exists, so that a lookup failing
+// for other reasons, e.g. a version pin that no ModuleTemplate satisfies, never uninstalls a module.
+func (r *KymaReconciler) markOrphanedModules(ctx context.Context, kyma *v1beta2.Kyma,
+	templates channel.ModuleTemplatesByModuleName,
+) {
+	policy := r.OrphanPolicy
+	if kyma.Spec.OrphanPolicy != nil {
+		policy = *kyma.Spec.OrphanPolicy
+	}
+	now := time.Now()
+	moduleStatusMap := kyma.GetModuleStatusMap()
+	for _, module := range kyma.Spec.Modules {
+		template, found := templates[module.Name]
+		// remote ModuleTemplates are tracked in the runtime, where they cannot be looked up from here.
+		if !found || module.RemoteModuleTemplateRef != "" ||
+			!errors.Is(template.Err, channel.ErrNoTemplatesInListResult) {
+			continue
+		}
+		moduleStatus, found := moduleStatusMap[module.Name]
+		if !found || moduleStatus.Template == nil ||
+			(moduleStatus.Manifest == nil && moduleStatus.OrphanedSince == nil) {
+			continue
+		}
+		templateKey := client.ObjectKey{
+			Namespace: moduleStatus.Template.GetNamespace(),
+			Name:      moduleStatus.Template.GetName(),
+		}
+		if err := r.Get(ctx, templateKey, &v1beta2.ModuleTemplate{}); !util.IsNotFound(err) {
+			continue
+		}
+
+		since := metav1.NewTime(now)
+		if moduleStatus.OrphanedSince != nil {
+			since = *moduleStatus.OrphanedSince
+		}
+		orphan := &channel.Orphan{Action: policy.Action, Since: since}
+		if policy.Action == v1beta2.OrphanActionUninstall {
+			orphan.UninstallAt = policy.UninstallAt(since.Time)
+			orphan.Uninstall = !now.Before(orphan.UninstallAt)
+		}
+		template.Orphan = orphan
+		template.Err = fmt.Errorf("%w: %s: %w", channel.ErrTemplateDeleted, templateKey, template.Err)
+	}
+}
+
+// recordOrphanedModules emits a warning for every orphaned module and an event once it is uninstalled.
+func (r *KymaReconciler) recordOrphanedModules(kyma *v1beta2.Kyma, templates channel.ModuleTemplatesByModuleName) {
+	moduleStatusMap := kyma.GetModuleStatusMap()
+	for moduleName, template := range templates {
+		if template.Orphan == nil {
+			continue
+		}
+		r.enqueueWarningEvent(kyma, moduleTemplateDeleted, fmt.Errorf("module %s (orphan policy %s): %w",
+			moduleName, template.Orphan.Action, template.Err))
+		if moduleStatus := moduleStatusMap[moduleName]; template.Orphan.Uninstall &&
+			moduleStatus != nil && moduleStatus.Manifest != nil {
+			r.enqueueNormalEvent(kyma, orphanedModuleUninstall, fmt.Sprintf(
+				"module %s is uninstalled, as its ModuleTemplate was deleted at least %s ago",
+				moduleName, template.Orphan.UninstallAt.Sub(template.Orphan.Since.Time)))
+		}
+	}
+}
+
+// deferUpgradesOutsideMaintenanceWindow defers changes of the ModuleTemplate used by already installed modules
+// while no maintenance window of the Kyma is open. If the maintenance windows are invalid, all changes are deferred
+// and the error is returned to be recorded.
+func deferUpgradesOutsideMaintenanceWindow(kyma *v1beta2.Kyma,
+	templates channel.ModuleTemplatesByModuleName,
+) error {
+	if len(kyma.Spec.MaintenanceWindows) == 0 {
+		meta.RemoveStatusCondition(&kyma.Status.Conditions, string(v1beta2.ConditionTypeModuleUpgrades))
+		return nil
+	}
+
+	now := time.Now()
+	var deferredErr error
+	open, err := kyma.IsInMaintenanceWindow(now)
+	if err != nil {
+		deferredErr = fmt.Errorf("%w: %w", channel.ErrTemplateUpdateDeferred, err)
+	} else if !open {
+		next, err := kyma.NextMaintenanceWindow(now)
+		if err != nil {
+			deferredErr = fmt.Errorf("%w: %w", channel.ErrTemplateUpdateDeferred, err)
+		} else {
+			deferredErr = fmt.Errorf("%w: outside of maintenance window, next window opens at %s",
+				channel.ErrTemplateUpdateDeferred, next.Format(time.RFC3339))
+		}
+	}
+
+	deferred := false
+	if deferredErr != nil {
+		moduleStatusMap := kyma.GetModuleStatusMap()
+		for moduleName, template := range templates {
+			if template.Err != nil || template.ModuleTemplate == nil {
+				continue
+			}
+			moduleStatus, found := moduleStatusMap[moduleName]
+			if !found || moduleStatus.Template == nil {
+				continue
+			}
+			if moduleStatus.Template.GetName() != template.GetName() ||
+				moduleStatus.Template.GetGeneration() != template.GetGeneration() {
+				template.Err = deferredErr
+				deferred = true
+			}
+		}
+	}
+
+	if deferred {
+		kyma.UpdateCondition(v1beta2.ConditionTypeModuleUpgrades, metav1.ConditionFalse)
+	} else {
+		kyma.UpdateCondition(v1beta2.ConditionTypeModuleUpgrades, metav1.ConditionTrue)
+	}
+	return err
+}
+
+// checkModuleRollouts defers the adoption of a new ModuleTemplate generation for all modules
+// whose ModuleRollout does not admit the Kyma yet.
+func (r *KymaReconciler) checkModuleRollouts(ctx context.Context, kyma *v1beta2.Kyma,
+	templates channel.ModuleTemplatesByModuleName, gate *rollout.Gate,
+) {
+	moduleStatusMap := kyma.GetModuleStatusMap()
+	for moduleName, template := range templates {
+		if template.Err != nil || template.ModuleTemplate == nil {
+			continue
+		}
+		moduleStatus, found := moduleStatusMap[moduleName]
+		// new installations and changes to another ModuleTemplate are not part of a rollout.
+		if !found || moduleStatus.Template == nil || moduleStatus.Template.GetName() != template.GetName() {
+			continue
+		}
+		if err := gate.Check(ctx, kyma, template.ModuleTemplate, moduleStatus); err != nil {
+			template.Err = err
+		}
+	}
 }
 
 func (r *KymaReconciler) DeleteNoLongerExistingModules(ctx context.Context, kyma *v1beta2.Kyma) error {
@@ -510,11 +815,21 @@ func (r *KymaReconciler) DeleteNoLongerExistingModules(ctx context.Context, kyma
 	if len(moduleStatus) == 0 {
 		return nil
 	}
+	dependencies, err := r.fetchModuleDependencies(ctx, kyma)
+	if err != nil {
+		return err
+	}
 	for i := range moduleStatus {
 		moduleStatus := moduleStatus[i]
 		if moduleStatus.Manifest == nil {
 			continue
 		}
+		// a module is only deleted once no other module depends on it anymore.
+		if dependents := dependentModules(dependencies, moduleStatus.Name); len(dependents) > 0 {
+			moduleStatus.Message = fmt.Sprintf("deletion waits for dependent modules: %s",
+				strings.Join(dependents, ", "))
+			continue
+		}
 		err = r.deleteManifest(ctx, moduleStatus.Manifest)
 	}
 
@@ -524,6 +839,78 @@ func (r *KymaReconciler) DeleteNoLongerExistingModules(ctx context.Context, kyma
 	return nil
 }
 
+// deleteManifestsInDependencyOrder deletes the Manifests of all modules in reverse order of their dependencies.
+// It returns true once all Manifests are gone, or immediately if no module declares any dependency,
+// as in that case the Manifests are cleaned up together with the Kyma through their owner reference.
+func (r *KymaReconciler) deleteManifestsInDependencyOrder(ctx context.Context, kyma *v1beta2.Kyma) (bool, error) {
+	dependencies, err := r.fetchModuleDependencies(ctx, kyma)
+	if err != nil {
+		return false, err
+	}
+	if !hasModuleDependencies(dependencies) {
+		return true, nil
+	}
+	moduleStatusMap := kyma.GetModuleStatusMap()
+	for moduleName := range dependencies {
+		if len(dependentModules(dependencies, moduleName)) > 0 {
+			continue
+		}
+		if err := r.deleteManifest(ctx, moduleStatusMap[moduleName].Manifest); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("error deleting module %s: %w", moduleName, err)
+		}
+	}
+	return false, nil
+}
+
+// fetchModuleDependencies returns the dependencies recorded on the Manifest of every module of the Kyma,
+// indexed by module name. Modules without an existing Manifest are not part of the result.
+func (r *KymaReconciler) fetchModuleDependencies(ctx context.Context, kyma *v1beta2.Kyma) (
+	map[string][]string, error,
+) {
+	dependencies := make(map[string][]string, len(kyma.Status.Modules))
+	for _, moduleStatus := range kyma.Status.Modules {
+		if moduleStatus.Manifest == nil {
+			continue
+		}
+		manifest := &v1beta2.Manifest{}
+		err := r.Get(ctx, client.ObjectKey{
+			Namespace: moduleStatus.Manifest.GetNamespace(),
+			Name:      moduleStatus.Manifest.GetName(),
+		}, manifest)
+		if util.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get manifest of module %s: %w", moduleStatus.Name, err)
+		}
+		dependencies[moduleStatus.Name] = nil
+		if value := manifest.GetAnnotations()[v1beta2.DependenciesAnnotation]; value != "" {
+			dependencies[moduleStatus.Name] = strings.Split(value, ",")
+		}
+	}
+	return dependencies, nil
+}
+
+func hasModuleDependencies(dependencies map[string][]string) bool {
+	for _, moduleDependencies := range dependencies {
+		if len(moduleDependencies) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func dependentModules(dependencies map[string][]string, moduleName string) []string {
+	var dependents []string
+	for dependent, moduleDependencies := range dependencies {
+		if dependent != moduleName && slices.Contains(moduleDependencies, moduleName) {
+			dependents = append(dependents, dependent)
+		}
+	}
+	slices.Sort(dependents)
+	return dependents
+}
+
 func (r *KymaReconciler) deleteManifest(ctx context.Context, trackedManifest *v1beta2.TrackingObject) error {
 	manifest := metav1.PartialObjectMetadata{}
 	manifest.SetGroupVersionKind(trackedManifest.GroupVersionKind())
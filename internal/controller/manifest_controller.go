@@ -68,7 +68,7 @@ func SetupWithManager(
 			},
 		).WithOptions(options)
 
-	if err := controllerManagedByManager.Complete(ManifestReconciler(mgr, checkInterval)); err != nil {
+	if err := controllerManagedByManager.Complete(ManifestReconciler(mgr, checkInterval, settings)); err != nil {
 		return fmt.Errorf("failed to initialize manifest controller by manager: %w", err)
 	}
 	return nil
@@ -77,14 +77,14 @@ func SetupWithManager(
 func ManifestReconciler(
 	mgr manager.Manager,
 	checkInterval time.Duration,
+	settings SetupUpSetting,
 ) *declarative.Reconciler {
 	kcp := &declarative.ClusterInfo{
 		Client: mgr.GetClient(),
 		Config: mgr.GetConfig(),
 	}
 	lookup := &manifest.RemoteClusterLookup{KCP: kcp}
-	return declarative.NewFromManager(
-		mgr, &v1beta2.Manifest{},
+	options := []declarative.Option{
 		declarative.WithSpecResolver(
 			manifest.NewSpecResolver(kcp),
 		),
@@ -96,5 +96,12 @@ func ManifestReconciler(
 		declarative.WithPeriodicConsistencyCheck(checkInterval),
 		declarative.WithModuleCRDName(manifest.GetModuleCRDName),
 		declarative.WithModuleCRDeletionCheck(manifest.NewModuleCRDeletionCheck()),
-	)
+		declarative.WithPruneProtection(settings.PruneProtection),
+	}
+	if settings.DriftDetection == manifest.DriftDetectionReport ||
+		settings.DriftDetection == manifest.DriftDetectionCorrect {
+		options = append(options,
+			declarative.WithDriftCheck(manifest.NewCustomResourceDriftCheck(settings.DriftDetection)))
+	}
+	return declarative.NewFromManager(mgr, &v1beta2.Manifest{}, options...)
 }
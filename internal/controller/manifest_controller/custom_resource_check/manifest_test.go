@@ -162,6 +162,8 @@ func setDeploymentStatus(name string, deploy *appsv1.Deployment) func() error {
 			return err
 		}
 		deploy.Status.Replicas = *deploy.Spec.Replicas
+		deploy.Status.ObservedGeneration = deploy.Generation
+		deploy.Status.UpdatedReplicas = *deploy.Spec.Replicas
 		deploy.Status.ReadyReplicas = *deploy.Spec.Replicas
 		deploy.Status.AvailableReplicas = *deploy.Spec.Replicas
 		deploy.Status.Conditions = append(deploy.Status.Conditions,
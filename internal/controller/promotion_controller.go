@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlLog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/log"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/promotion"
+	"github.com/kyma-project/lifecycle-manager/pkg/util"
+)
+
+const (
+	modulePromoted   EventReasonInfo  = "ModulePromoted"
+	promotionBlocked EventReasonError = "PromotionBlocked"
+)
+
+// ModuleChannelPromotionReconciler promotes ModuleTemplates between channels as declared by ModuleChannelPromotions.
+type ModuleChannelPromotionReconciler struct {
+	client.Client
+	record.EventRecorder
+	Promoter *promotion.Promoter
+}
+
+//nolint:lll
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=modulechannelpromotions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=modulechannelpromotions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=moduletemplates,verbs=get;list;watch;create;update;patch
+
+func (r *ModuleChannelPromotionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrlLog.FromContext(ctx)
+	logger.V(log.DebugLevel).Info("reconciling")
+
+	modulePromotion := &v1beta2.ModuleChannelPromotion{}
+	if err := r.Get(ctx, req.NamespacedName, modulePromotion); err != nil {
+		if !util.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("ModuleChannelPromotionController: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+	if !modulePromotion.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	previous := modulePromotion.Status.DeepCopy()
+	requeueAfter, promoteErr := r.Promoter.Promote(ctx, modulePromotion)
+	current := &modulePromotion.Status
+
+	if len(current.History) > 0 &&
+		(len(previous.History) == 0 || !current.History[0].PromotedAt.Equal(&previous.History[0].PromotedAt)) {
+		r.Event(modulePromotion, "Normal", string(modulePromoted), current.Message)
+	}
+	if current.State != previous.State && current.State == v1beta2.PromotionStateBlocked {
+		r.Event(modulePromotion, "Warning", string(promotionBlocked), current.Message)
+	}
+
+	if !equality.Semantic.DeepEqual(previous, current) {
+		if err := r.Status().Update(ctx, modulePromotion); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status of ModuleChannelPromotion %s: %w",
+				req.NamespacedName, err)
+		}
+	}
+	if promoteErr != nil {
+		return ctrl.Result{}, promoteErr
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// promotionsForTemplate enqueues all ModuleChannelPromotions of the module of the ModuleTemplate.
+func (r *ModuleChannelPromotionReconciler) promotionsForTemplate(ctx context.Context,
+	template client.Object,
+) []reconcile.Request {
+	moduleName, found := template.GetLabels()[v1beta2.ModuleName]
+	if !found {
+		return nil
+	}
+	promotions := &v1beta2.ModuleChannelPromotionList{}
+	if err := r.List(ctx, promotions, client.InNamespace(template.GetNamespace())); err != nil {
+		ctrlLog.FromContext(ctx).Error(err, "failed to list ModuleChannelPromotions")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range promotions.Items {
+		if promotions.Items[i].Spec.Module == moduleName {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&promotions.Items[i]),
+			})
+		}
+	}
+	return requests
+}
@@ -20,11 +20,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	declarative "github.com/kyma-project/lifecycle-manager/internal/declarative/v2"
+	"github.com/kyma-project/lifecycle-manager/internal/manifest"
 
 	listener "github.com/kyma-project/runtime-watcher/listener/pkg/event"
 	"github.com/kyma-project/runtime-watcher/listener/pkg/types"
 
 	"github.com/kyma-project/lifecycle-manager/pkg/istio"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/plan"
 	"github.com/kyma-project/lifecycle-manager/pkg/security"
 	"github.com/kyma-project/lifecycle-manager/pkg/watch"
 )
@@ -33,6 +36,11 @@ type SetupUpSetting struct {
 	ListenerAddr                 string
 	EnableDomainNameVerification bool
 	IstioNamespace               string
+	DriftDetection               manifest.DriftDetectionMode
+	// PruneProtection protects resources dropped from a module manifest from being deleted.
+	PruneProtection declarative.PruneProtection
+	// PlanAddr is the address the plan endpoint binds to, the endpoint is disabled if empty.
+	PlanAddr string
 }
 
 const (
@@ -91,6 +99,20 @@ func (r *KymaReconciler) SetupWithManager(mgr ctrl.Manager,
 		return fmt.Errorf("KymaReconciler %w", err)
 	}
 
+	if settings.PlanAddr != "" {
+		planServer := &plan.Server{
+			Addr: settings.PlanAddr,
+			Handler: &plan.Handler{
+				Reader:     mgr.GetClient(),
+				Generator:  r,
+				Authorizer: &plan.AccessReviewAuthorizer{Client: mgr.GetClient()},
+			},
+		}
+		if err := mgr.Add(planServer); err != nil {
+			return fmt.Errorf("KymaReconciler %w", err)
+		}
+	}
+
 	if err := controllerBuilder.Complete(r); err != nil {
 		return fmt.Errorf("error occurred while building controller: %w", err)
 	}
@@ -174,3 +196,23 @@ func (r *PurgeReconciler) SetupWithManager(mgr ctrl.Manager,
 
 	return nil
 }
+
+// SetupWithManager sets up the ModuleChannelPromotion controller with the Manager.
+func (r *ModuleChannelPromotionReconciler) SetupWithManager(mgr ctrl.Manager,
+	options controllerRuntime.Options,
+) error {
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta2.ModuleChannelPromotion{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(options).
+		Watches(
+			&v1beta2.ModuleTemplate{},
+			handler.EnqueueRequestsFromMapFunc(r.promotionsForTemplate),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		)
+
+	if err := controllerBuilder.Complete(r); err != nil {
+		return fmt.Errorf("error occurred while building controller: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package v2
+
+import (
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// ApplyPhase orders the resources of a manifest, so that resources are applied after the resources they depend on,
+// and are deleted before them.
+type ApplyPhase int
+
+const (
+	// PhaseDefinitions contains Namespaces and CustomResourceDefinitions, which other resources are created in or of.
+	PhaseDefinitions ApplyPhase = iota
+	// PhaseConfiguration contains RBAC and configuration, which workloads refer to.
+	PhaseConfiguration
+	// PhaseWorkloads contains all other resources, e.g. workloads and custom resources.
+	PhaseWorkloads
+)
+
+// phaseConcurrencyLimit bounds the resources that are applied or deleted at the same time within a phase.
+const phaseConcurrencyLimit = 10
+
+const rbacGroup = "rbac.authorization.k8s.io"
+
+var phasesByKind = map[schema.GroupKind]ApplyPhase{ //nolint:gochecknoglobals
+	{Kind: "Namespace"}:                            PhaseDefinitions,
+	crdGroupKind:                                   PhaseDefinitions,
+	{Kind: "ServiceAccount"}:                       PhaseConfiguration,
+	{Kind: "ConfigMap"}:                            PhaseConfiguration,
+	{Kind: "Secret"}:                               PhaseConfiguration,
+	{Group: rbacGroup, Kind: "Role"}:               PhaseConfiguration,
+	{Group: rbacGroup, Kind: "RoleBinding"}:        PhaseConfiguration,
+	{Group: rbacGroup, Kind: "ClusterRole"}:        PhaseConfiguration,
+	{Group: rbacGroup, Kind: "ClusterRoleBinding"}: PhaseConfiguration,
+}
+
+var crdGroupKind = apiextensionsv1.Kind("CustomResourceDefinition") //nolint:gochecknoglobals
+
+func groupKindOf(info *resource.Info) schema.GroupKind {
+	if info.Mapping != nil {
+		return info.Mapping.GroupVersionKind.GroupKind()
+	}
+	return info.Object.GetObjectKind().GroupVersionKind().GroupKind()
+}
+
+func applyPhaseOf(info *resource.Info) ApplyPhase {
+	if phase, found := phasesByKind[groupKindOf(info)]; found {
+		return phase
+	}
+	return PhaseWorkloads
+}
+
+// inPhases groups the resources by their ApplyPhase, in the order of the phases.
+func inPhases(infos []*resource.Info) [][]*resource.Info {
+	phases := make([][]*resource.Info, PhaseWorkloads+1)
+	for _, info := range infos {
+		phase := applyPhaseOf(info)
+		phases[phase] = append(phases[phase], info)
+	}
+	return phases
+}
+
+// runConcurrently runs the function for all resources, at most limit of them at the same time,
+// and returns the error of every resource at its index.
+func runConcurrently(infos []*resource.Info, limit int, run func(info *resource.Info) error) []error {
+	errs := make([]error, len(infos))
+	semaphore := make(chan struct{}, limit)
+	var waitGroup sync.WaitGroup
+	for i := range infos {
+		i := i
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+			errs[i] = run(infos[i])
+		}()
+	}
+	waitGroup.Wait()
+	return errs
+}
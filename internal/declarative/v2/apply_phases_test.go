@@ -0,0 +1,81 @@
+//nolint:testpackage
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func phaseInfo(obj *unstructured.Unstructured) *resource.Info {
+	return &resource.Info{Namespace: obj.GetNamespace(), Name: obj.GetName(), Object: obj}
+}
+
+func Test_inPhases(t *testing.T) {
+	t.Parallel()
+
+	phases := inPhases([]*resource.Info{
+		phaseInfo(newResource("apps/v1", "Deployment", "kyma-system", "operator")),
+		phaseInfo(newResource("example.io/v1", "Sample", "kyma-system", "sample")),
+		phaseInfo(newResource("rbac.authorization.k8s.io/v1", "ClusterRole", "", "operator")),
+		phaseInfo(newResource("v1", "ServiceAccount", "kyma-system", "operator")),
+		phaseInfo(newCRD("example.io", "Sample", "Namespaced")),
+		phaseInfo(newResource("v1", "Namespace", "", "kyma-system")),
+	})
+
+	kinds := make([][]string, 0, len(phases))
+	for _, phase := range phases {
+		var phaseKinds []string
+		for _, info := range phase {
+			phaseKinds = append(phaseKinds, groupKindOf(info).Kind)
+		}
+		kinds = append(kinds, phaseKinds)
+	}
+	assert.Equal(t, [][]string{
+		PhaseDefinitions:   {"CustomResourceDefinition", "Namespace"},
+		PhaseConfiguration: {"ClusterRole", "ServiceAccount"},
+		PhaseWorkloads:     {"Deployment", "Sample"},
+	}, kinds)
+}
+
+func TestConcurrentCleanup_ReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	clnt := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kyma-system"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "kyma-system"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "operator", Namespace: "kyma-system"}},
+	).Build()
+	infos := []*resource.Info{
+		phaseInfo(newResource("v1", "Namespace", "", "kyma-system")),
+		phaseInfo(newResource("v1", "ConfigMap", "kyma-system", "config")),
+		phaseInfo(newResource("apps/v1", "Deployment", "kyma-system", "operator")),
+	}
+	exists := func(obj client.Object) bool {
+		return clnt.Get(context.Background(), client.ObjectKeyFromObject(obj), obj) == nil
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kyma-system"}}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "kyma-system"}}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "operator", Namespace: "kyma-system"}}
+	cleanup := NewConcurrentCleanup(clnt)
+
+	require.ErrorIs(t, cleanup.Run(context.Background(), infos), ErrDeletionNotFinished)
+	assert.Equal(t, []bool{true, true, false}, []bool{exists(namespace), exists(configMap), exists(deployment)})
+
+	require.ErrorIs(t, cleanup.Run(context.Background(), infos), ErrDeletionNotFinished)
+	assert.Equal(t, []bool{true, false, false}, []bool{exists(namespace), exists(configMap), exists(deployment)})
+
+	require.ErrorIs(t, cleanup.Run(context.Background(), infos), ErrDeletionNotFinished)
+	assert.False(t, exists(namespace))
+
+	require.NoError(t, cleanup.Run(context.Background(), infos))
+}
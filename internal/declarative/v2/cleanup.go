@@ -3,6 +3,7 @@ package v2
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/kyma-project/lifecycle-manager/pkg/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,18 +26,24 @@ func NewConcurrentCleanup(clnt client.Client) Cleanup {
 	return &ConcurrentCleanup{clnt: clnt, policy: client.PropagationPolicy(metav1.DeletePropagationBackground)}
 }
 
+// Run deletes the resources in the reverse order of their ApplyPhase, so that a phase is only deleted once
+// the resources of all later phases are gone. Within a phase, the resources are deleted concurrently.
 func (c *ConcurrentCleanup) Run(ctx context.Context, infos []*resource.Info) error {
-	// The Runtime Complexity of this Branch is N as only ServerSideApplier Patch is required
-	results := make(chan error, len(infos))
-	for i := range infos {
-		i := i
-		go c.cleanupResource(ctx, infos[i], results)
+	phases := inPhases(infos)
+	for phase := len(phases) - 1; phase >= 0; phase-- {
+		if err := c.cleanupPhase(ctx, phases[phase]); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+func (c *ConcurrentCleanup) cleanupPhase(ctx context.Context, infos []*resource.Info) error {
 	var errs []error
 	present := len(infos)
-	for i := 0; i < len(infos); i++ {
-		err := <-results
+	for _, err := range runConcurrently(infos, phaseConcurrencyLimit, func(info *resource.Info) error {
+		return c.cleanupResource(ctx, info)
+	}) {
 		if util.IsNotFound(err) {
 			present--
 			continue
@@ -56,10 +63,10 @@ func (c *ConcurrentCleanup) Run(ctx context.Context, infos []*resource.Info) err
 	return nil
 }
 
-func (c *ConcurrentCleanup) cleanupResource(ctx context.Context, info *resource.Info, results chan error) {
+func (c *ConcurrentCleanup) cleanupResource(ctx context.Context, info *resource.Info) error {
 	obj, ok := info.Object.(client.Object)
 	if !ok {
-		return
+		return fmt.Errorf("%s is not a valid client-go object: %w", info.ObjectName(), ErrClientObjectConversionFailed)
 	}
-	results <- c.clnt.Delete(ctx, obj, c.policy)
+	return c.clnt.Delete(ctx, obj, c.policy) //nolint:wrapcheck
 }
@@ -0,0 +1,101 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var ErrDriftConditionRequiresUpdate = errors.New("drift condition needs an update")
+
+const (
+	ConditionTypeModuleCRSync ConditionType = "ModuleCRSync"
+
+	ConditionReasonInSync         ConditionReason = "InSync"
+	ConditionReasonDriftDetected  ConditionReason = "DriftDetected"
+	ConditionReasonDriftCorrected ConditionReason = "DriftCorrected"
+
+	maxReportedDriftFields = 5
+)
+
+// DriftCheck compares a resource in the target cluster that is not part of the rendered manifest,
+// e.g. the module CR, with its desired state.
+type DriftCheck interface {
+	// Run returns nil if there is no resource to compare for the object.
+	Run(ctx context.Context, clnt Client, obj Object) (*DriftInfo, error)
+}
+
+type DriftInfo struct {
+	// Fields describes every drifted field of the resource.
+	Fields []string
+	// Corrected is true if the desired state of the drifted fields was re-applied.
+	Corrected bool
+}
+
+func newModuleCRSyncCondition(obj Object, drift *DriftInfo) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(ConditionTypeModuleCRSync),
+		Reason:             string(ConditionReasonInSync),
+		Status:             metav1.ConditionTrue,
+		Message:            "module CR matches its desired state",
+		ObservedGeneration: obj.GetGeneration(),
+	}
+	if len(drift.Fields) == 0 {
+		return condition
+	}
+
+	fields := drift.Fields
+	if len(fields) > maxReportedDriftFields {
+		fields = append(fields[:maxReportedDriftFields:maxReportedDriftFields],
+			fmt.Sprintf("and %d more", len(drift.Fields)-maxReportedDriftFields))
+	}
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = string(ConditionReasonDriftDetected)
+	condition.Message = "module CR drifted from its desired state: " + strings.Join(fields, "; ")
+	if drift.Corrected {
+		condition.Reason = string(ConditionReasonDriftCorrected)
+		condition.Message += "; the desired state was re-applied"
+	}
+	return condition
+}
+
+func (r *Reconciler) checkDrift(ctx context.Context, clnt Client, obj Object) error {
+	if r.DriftCheck == nil {
+		return nil
+	}
+	status := obj.GetStatus()
+
+	drift, err := r.DriftCheck.Run(ctx, clnt, obj)
+	if err != nil {
+		// drift detection does not fail the reconciliation, a detected drift is reported nevertheless.
+		r.Event(obj, "Warning", "DriftCheck", err.Error())
+		if drift == nil {
+			return nil
+		}
+	}
+	if drift == nil {
+		if meta.FindStatusCondition(status.Conditions, string(ConditionTypeModuleCRSync)) != nil {
+			meta.RemoveStatusCondition(&status.Conditions, string(ConditionTypeModuleCRSync))
+			obj.SetStatus(status)
+			return ErrDriftConditionRequiresUpdate
+		}
+		return nil
+	}
+
+	condition := newModuleCRSyncCondition(obj, drift)
+	if existing := meta.FindStatusCondition(status.Conditions, condition.Type); existing != nil &&
+		existing.Status == condition.Status && existing.Reason == condition.Reason &&
+		existing.Message == condition.Message {
+		return nil
+	}
+	if condition.Status == metav1.ConditionFalse {
+		r.Event(obj, "Warning", condition.Reason, condition.Message)
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+	obj.SetStatus(status)
+	return ErrDriftConditionRequiresUpdate
+}
@@ -23,6 +23,7 @@ const (
 	FieldOwnerDefault       = "declarative.kyma-project.io/applier"
 	EventRecorderDefault    = "declarative.kyma-project.io/events"
 	SkipReconcileLabel      = "operator.kyma-project.io/skip-reconciliation"
+	PausedLabel             = "operator.kyma-project.io/paused"
 	DefaultInMemoryParseTTL = 24 * time.Hour
 )
 
@@ -44,6 +45,7 @@ func DefaultOptions() *Options {
 		WithSkipReconcileOn(SkipReconcileOnDefaultLabelPresentAndTrue),
 		WithManifestParser(NewInMemoryCachedManifestParser(DefaultInMemoryParseTTL)),
 		WithModuleCRDeletionCheck(NewDefaultDeletionCheck()),
+		WithPruneProtection(PruneProtection{Kinds: DefaultPruneProtectedKinds}),
 	)
 }
 
@@ -59,6 +61,7 @@ type Options struct {
 	ManifestParser
 	ManifestCache
 	CustomReadyCheck ReadyCheck
+	DriftCheck       DriftCheck
 
 	Namespace       string
 	CreateNamespace bool
@@ -77,6 +80,8 @@ type Options struct {
 
 	DeletionCheck ModuleCRDeletionCheck
 
+	PruneProtection PruneProtection
+
 	DeletePrerequisites bool
 
 	ShouldSkip SkipReconcile
@@ -293,6 +298,30 @@ func (o WithCustomReadyCheckOption) Apply(options *Options) {
 	options.CustomReadyCheck = o
 }
 
+type WithDriftCheckOption struct {
+	DriftCheck
+}
+
+func WithDriftCheck(check DriftCheck) WithDriftCheckOption {
+	return WithDriftCheckOption{DriftCheck: check}
+}
+
+func (o WithDriftCheckOption) Apply(options *Options) {
+	options.DriftCheck = o.DriftCheck
+}
+
+type WithPruneProtectionOption struct {
+	PruneProtection
+}
+
+func WithPruneProtection(protection PruneProtection) WithPruneProtectionOption {
+	return WithPruneProtectionOption{PruneProtection: protection}
+}
+
+func (o WithPruneProtectionOption) Apply(options *Options) {
+	options.PruneProtection = o.PruneProtection
+}
+
 type ClusterFn func(context.Context, Object) (*ClusterInfo, error)
 
 func WithRemoteTargetCluster(configFn ClusterFn) WithRemoteTargetClusterOption {
@@ -0,0 +1,153 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/pkg/util"
+)
+
+const (
+	// PruneProtectedAnnotation protects a resource from being pruned if it is "true".
+	PruneProtectedAnnotation = OperatorPrefix + Separator + "prune-protected"
+	// PrunePreviewAnnotation holds back pruning of an object with the value "true", so that the resources
+	// that would be pruned can be reviewed before the annotation is removed.
+	PrunePreviewAnnotation = OperatorPrefix + Separator + "prune-preview"
+)
+
+var (
+	ErrPrunePreview               = errors.New("pruning is held back for preview")
+	ErrInvalidPruneProtectedKinds = errors.New("invalid prune protected kinds")
+)
+
+// orphanedResourceLimit bounds the Orphaned resources that are recorded in the status.
+const orphanedResourceLimit = 50
+
+// DefaultPruneProtectedKinds are the kinds that are never pruned by default, as they hold data.
+var DefaultPruneProtectedKinds = []schema.GroupKind{{Kind: "PersistentVolumeClaim"}}
+
+// PruneProtection decides which of the resources dropped from the rendered manifest are orphaned in the cluster
+// instead of being deleted. A resource is protected if its kind is one of the Kinds, if it is annotated with
+// PruneProtectedAnnotation, or if its labels match the Selector.
+type PruneProtection struct {
+	Selector labels.Selector
+	Kinds    []schema.GroupKind
+}
+
+// ParsePruneProtection parses a label selector and a comma-separated list of kinds,
+// e.g. "Secret,Certificate.cert-manager.io".
+func ParsePruneProtection(selector, kinds string) (PruneProtection, error) {
+	protection := PruneProtection{}
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return PruneProtection{}, fmt.Errorf("invalid prune protection selector %q: %w", selector, err)
+		}
+		protection.Selector = parsed
+	}
+	for _, kind := range strings.Split(kinds, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		groupKind := schema.ParseGroupKind(kind)
+		if groupKind.Kind == "" {
+			return PruneProtection{}, fmt.Errorf("%w: %q has no kind", ErrInvalidPruneProtectedKinds, kind)
+		}
+		protection.Kinds = append(protection.Kinds, groupKind)
+	}
+	return protection, nil
+}
+
+// Partition splits the resources to prune into the resources to delete and the protected resources. Annotations
+// and labels are read from the cluster, as the resources to prune are no longer part of the rendered manifest.
+func (p PruneProtection) Partition(ctx context.Context, clnt client.Client, diff []*resource.Info) (
+	[]*resource.Info, []*resource.Info, error,
+) {
+	deletable := make([]*resource.Info, 0, len(diff))
+	var protected []*resource.Info
+	for _, info := range diff {
+		isProtected, err := p.isProtected(ctx, clnt, info)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isProtected {
+			protected = append(protected, info)
+		} else {
+			deletable = append(deletable, info)
+		}
+	}
+	return deletable, protected, nil
+}
+
+func (p PruneProtection) isProtected(ctx context.Context, clnt client.Client, info *resource.Info) (bool, error) {
+	gvk := info.Object.GetObjectKind().GroupVersionKind()
+	for _, kind := range p.Kinds {
+		if kind == gvk.GroupKind() {
+			return true, nil
+		}
+	}
+
+	live := &metav1.PartialObjectMetadata{}
+	live.SetGroupVersionKind(gvk)
+	if err := clnt.Get(ctx, client.ObjectKey{Namespace: info.Namespace, Name: info.Name}, live); util.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to check prune protection of %s %s: %w", gvk.Kind, info.Name, err)
+	}
+	if live.GetAnnotations()[PruneProtectedAnnotation] == "true" {
+		return true, nil
+	}
+	return p.Selector != nil && p.Selector.Matches(labels.Set(live.GetLabels())), nil
+}
+
+func isPrunePreview(obj Object) bool {
+	return obj.GetDeletionTimestamp().IsZero() && obj.GetAnnotations()[PrunePreviewAnnotation] == "true"
+}
+
+// recordOrphaned adds the resource to the orphaned ones and drops the oldest ones beyond the limit of the status,
+// as orphaned resources are no longer synced and would otherwise accumulate with every version of a module.
+func recordOrphaned(orphaned []shared.Resource, res shared.Resource) []shared.Resource {
+	orphaned = append(orphaned, res)
+	if len(orphaned) > orphanedResourceLimit {
+		return orphaned[len(orphaned)-orphanedResourceLimit:]
+	}
+	return orphaned
+}
+
+// withoutResources removes the resources from the orphaned ones, e.g. once they are synced again.
+func withoutResources(orphaned []shared.Resource, resources []shared.Resource) []shared.Resource {
+	var remaining []shared.Resource
+	for _, res := range orphaned {
+		if !containsResource(resources, res) {
+			remaining = append(remaining, res)
+		}
+	}
+	return remaining
+}
+
+func containsResource(resources []shared.Resource, res shared.Resource) bool {
+	for _, candidate := range resources {
+		if candidate.ID() == res.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceIDs(resources []shared.Resource) string {
+	ids := make([]string, 0, len(resources))
+	for _, res := range resources {
+		ids = append(ids, res.ID())
+	}
+	return strings.Join(ids, ", ")
+}
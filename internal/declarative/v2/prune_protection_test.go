@@ -0,0 +1,97 @@
+//nolint:testpackage
+package v2
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+)
+
+func TestParsePruneProtection(t *testing.T) {
+	t.Parallel()
+
+	protection, err := ParsePruneProtection("protected=true", "PersistentVolumeClaim, Certificate.cert-manager.io")
+	require.NoError(t, err)
+	assert.Equal(t, []schema.GroupKind{
+		{Kind: "PersistentVolumeClaim"}, {Group: "cert-manager.io", Kind: "Certificate"},
+	}, protection.Kinds)
+	assert.Equal(t, "protected=true", protection.Selector.String())
+
+	_, err = ParsePruneProtection("", ".cert-manager.io")
+	require.ErrorIs(t, err, ErrInvalidPruneProtectedKinds)
+	_, err = ParsePruneProtection("protected in (", "")
+	require.Error(t, err)
+}
+
+func pruneInfo(kind, name string) *resource.Info {
+	return &resource.Info{
+		Namespace: metav1.NamespaceDefault,
+		Name:      name,
+		Object:    newResource("v1", kind, metav1.NamespaceDefault, name),
+	}
+}
+
+func TestPruneProtection_Partition(t *testing.T) {
+	t.Parallel()
+
+	clnt := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name: "annotated", Namespace: metav1.NamespaceDefault,
+			Annotations: map[string]string{PruneProtectedAnnotation: "true"},
+		}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "labeled", Namespace: metav1.NamespaceDefault,
+			Labels: map[string]string{"protected": "true"},
+		}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "unprotected", Namespace: metav1.NamespaceDefault,
+		}},
+	).Build()
+	protection, err := ParsePruneProtection("protected=true", "PersistentVolumeClaim")
+	require.NoError(t, err)
+
+	deletable, protected, err := protection.Partition(context.Background(), clnt, []*resource.Info{
+		pruneInfo("PersistentVolumeClaim", "data"),
+		pruneInfo("Secret", "annotated"),
+		pruneInfo("ConfigMap", "labeled"),
+		pruneInfo("ConfigMap", "unprotected"),
+		pruneInfo("ConfigMap", "missing"),
+	})
+
+	require.NoError(t, err)
+	names := func(infos []*resource.Info) []string {
+		var names []string
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"data", "annotated", "labeled"}, names(protected))
+	assert.Equal(t, []string{"unprotected", "missing"}, names(deletable))
+}
+
+func TestRecordOrphaned_DropsOldest(t *testing.T) {
+	t.Parallel()
+
+	var orphaned []shared.Resource
+	for i := 0; i <= orphanedResourceLimit; i++ {
+		orphaned = recordOrphaned(orphaned, shared.Resource{
+			Name: "orphan-" + strconv.Itoa(i), Namespace: metav1.NamespaceDefault,
+			GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		})
+	}
+
+	require.Len(t, orphaned, orphanedResourceLimit)
+	assert.Equal(t, "orphan-1", orphaned[0].Name)
+	assert.Equal(t, "orphan-"+strconv.Itoa(orphanedResourceLimit), orphaned[orphanedResourceLimit-1].Name)
+}
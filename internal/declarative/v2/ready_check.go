@@ -15,6 +15,8 @@ var ErrNotValidClientObject = errors.New("object in resource info is not a valid
 type StateInfo struct {
 	shared.State
 	Info string
+	// Readiness is the readiness of the individual resources, if the ReadyCheck evaluates them.
+	Readiness []shared.ResourceReadiness
 }
 
 type ReadyCheck interface {
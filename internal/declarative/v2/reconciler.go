@@ -33,6 +33,8 @@ const (
 	namespaceNotBeRemoved  = "kyma-system"
 	CustomResourceManager  = "resource.kyma-project.io/finalizer"
 	SyncedOCIRefAnnotation = "sync-oci-ref"
+	pausedOperation        = "reconciliation is paused, resources are neither applied nor pruned"
+	resumedOperation       = "reconciliation is resumed"
 )
 
 func NewFromManager(mgr manager.Manager, prototype Object, options ...Option) *Reconciler {
@@ -110,6 +112,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	if obj.GetDeletionTimestamp().IsZero() && isPaused(obj) {
+		return r.pause(ctx, obj)
+	}
+	if obj.GetStatus().LastOperation.Operation == pausedOperation {
+		r.Event(obj, "Normal", "Resumed", resumedOperation)
+		obj.SetStatus(obj.GetStatus().WithOperation(resumedOperation))
+		return r.ssaStatus(ctx, obj)
+	}
+
 	spec, err := r.Spec(ctx, obj)
 	if err != nil {
 		if !obj.GetDeletionTimestamp().IsZero() {
@@ -149,7 +160,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	diff := ResourceList(current).Difference(target)
-	if err := r.pruneDiff(ctx, clnt, obj, renderer, diff, spec); errors.Is(err, ErrDeletionNotFinished) {
+	heldBack, err := r.pruneDiff(ctx, clnt, obj, renderer, diff, spec)
+	if errors.Is(err, ErrDeletionNotFinished) {
 		return ctrl.Result{Requeue: true}, nil
 	} else if err != nil {
 		return r.ssaStatus(ctx, obj)
@@ -158,7 +170,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if !obj.GetDeletionTimestamp().IsZero() {
 		return r.removeFinalizers(ctx, obj, []string{r.Finalizer})
 	}
-	if err := r.syncResources(ctx, clnt, obj, target); err != nil {
+	if err := r.syncResources(ctx, clnt, obj, spec, target, heldBack); err != nil {
+		return r.ssaStatus(ctx, obj)
+	}
+	if len(heldBack) > 0 {
+		// the synced OCI ref is only updated once the resources are pruned, as a diff for a synced OCI ref
+		// is treated as an incomplete cache.
+		r.reportPrunePreview(obj, heldBack)
 		return r.ssaStatus(ctx, obj)
 	}
 
@@ -171,6 +189,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	return r.CtrlOnSuccess, nil
 }
 
+func isPaused(obj Object) bool {
+	return obj.GetLabels()[PausedLabel] == "true"
+}
+
+// pause neither applies nor prunes any resource of a paused object, only the pause is reported in its status.
+func (r *Reconciler) pause(ctx context.Context, obj Object) (ctrl.Result, error) {
+	status := obj.GetStatus()
+	if status.LastOperation.Operation == pausedOperation {
+		return r.CtrlOnSuccess, nil
+	}
+	r.Event(obj, "Normal", "Paused", pausedOperation)
+	obj.SetStatus(status.WithOperation(pausedOperation))
+	return r.ssaStatus(ctx, obj)
+}
+
 func (r *Reconciler) removeFinalizers(ctx context.Context, obj Object, finalizersToRemove []string) (
 	ctrl.Result, error,
 ) {
@@ -272,18 +305,33 @@ func (r *Reconciler) renderResources(
 	return target, current, nil
 }
 
-func (r *Reconciler) syncResources(ctx context.Context, clnt Client, obj Object, target []*resource.Info) error {
+// syncResources applies the target resources. The resources held back by a prune preview stay synced,
+// so that they are pruned once the preview ends.
+func (r *Reconciler) syncResources(ctx context.Context, clnt Client, obj Object, spec *Spec,
+	target, heldBack []*resource.Info,
+) error {
 	status := obj.GetStatus()
 
-	if err := ConcurrentSSA(clnt, r.FieldOwner).Run(ctx, target); err != nil {
-		r.Event(obj, "Warning", "ServerSideApply", err.Error())
-		obj.SetStatus(status.WithState(shared.StateError).WithErr(err))
+	ssa := ConcurrentSSA(clnt, r.FieldOwner)
+	if ociRefNotChanged(obj, spec.OCIRef) {
+		// conflicts are only detected for resources applied for the first time or from a new manifest version.
+		ssa.ForceOwnershipOf(status.Synced)
+	}
+	err := ssa.Run(ctx, target)
+	r.reportConflicts(obj, ssa.Conflicts())
+	if err != nil {
+		r.recordApplyErrors(obj, status, err)
 		return err
 	}
+	status.ResourceErrors = nil
 
 	oldSynced := status.Synced
-	newSynced := NewInfoToResourceConverter().InfosToResources(target)
+	converter := NewInfoToResourceConverter()
+	newSynced := append(converter.InfosToResources(target), converter.InfosToResources(heldBack)...)
 	status.Synced = newSynced
+	// resources that were orphaned and are part of the manifest again are managed again.
+	status.Orphaned = withoutResources(status.Orphaned, newSynced)
+	obj.SetStatus(status)
 
 	if hasDiff(oldSynced, newSynced) {
 		obj.SetStatus(status.WithState(shared.StateProcessing).WithOperation(ErrWarningResourceSyncStateDiff.Error()))
@@ -298,9 +346,39 @@ func (r *Reconciler) syncResources(ctx context.Context, clnt Client, obj Object,
 		}
 	}
 
+	if err := r.checkDrift(ctx, clnt, obj); err != nil {
+		return err
+	}
+
 	return r.checkTargetReadiness(ctx, clnt, obj, target)
 }
 
+// recordApplyErrors records the resources that failed to be applied in the status, and emits one event
+// per kind of error instead of one for every resource.
+func (r *Reconciler) recordApplyErrors(obj Object, status shared.Status, err error) {
+	resourceErrors := resourceErrorsOf(err)
+	if len(resourceErrors) == 0 {
+		r.Event(obj, "Warning", "ServerSideApply", err.Error())
+		obj.SetStatus(status.WithState(shared.StateError).WithErr(err))
+		return
+	}
+	byKind := resourceErrorsByKind(resourceErrors)
+	for _, kind := range kindsInOrder(resourceErrors) {
+		r.Event(obj, "Warning", "ServerSideApply", describeResourceErrors(kind, byKind[kind]))
+	}
+	status.ResourceErrors = limitResourceErrors(resourceErrors)
+	obj.SetStatus(status.WithState(shared.StateError).WithErr(summarizeResourceErrors(resourceErrors)))
+}
+
+// reportConflicts emits one event for the resources whose fields were taken over from other field managers.
+// They were applied, so they are not recorded as ResourceErrors.
+func (r *Reconciler) reportConflicts(obj Object, conflicts error) {
+	if resourceErrors := resourceErrorsOf(conflicts); len(resourceErrors) > 0 {
+		r.Event(obj, "Warning", "ServerSideApply", "fields were taken over: "+
+			describeResourceErrors(shared.ResourceErrorConflict, resourceErrors))
+	}
+}
+
 func hasDiff(oldResources []shared.Resource, newResources []shared.Resource) bool {
 	if len(oldResources) != len(newResources) {
 		return true
@@ -336,6 +414,9 @@ func (r *Reconciler) checkTargetReadiness(
 		return err
 	}
 
+	readinessChanged := hasReadinessDiff(status.Readiness, crStateInfo.Readiness)
+	status.Readiness = crStateInfo.Readiness
+
 	if crStateInfo.State == shared.StateProcessing {
 		waitingMsg := fmt.Sprintf("waiting for resources to become ready: %s", crStateInfo.Info)
 		r.Event(manifest, "Normal", "ResourceReadyCheck", waitingMsg)
@@ -344,7 +425,8 @@ func (r *Reconciler) checkTargetReadiness(
 	}
 
 	installationCondition := newInstallationCondition(manifest)
-	if !meta.IsStatusConditionTrue(status.Conditions, installationCondition.Type) || status.State != crStateInfo.State {
+	if !meta.IsStatusConditionTrue(status.Conditions, installationCondition.Type) ||
+		status.State != crStateInfo.State || readinessChanged {
 		r.Event(manifest, "Normal", installationCondition.Reason, installationCondition.Message)
 		installationCondition.Status = metav1.ConditionTrue
 		meta.SetStatusCondition(&status.Conditions, installationCondition)
@@ -356,6 +438,18 @@ func (r *Reconciler) checkTargetReadiness(
 	return nil
 }
 
+func hasReadinessDiff(oldReadiness, newReadiness []shared.ResourceReadiness) bool {
+	if len(oldReadiness) != len(newReadiness) {
+		return true
+	}
+	for i := range oldReadiness {
+		if oldReadiness[i] != newReadiness[i] {
+			return true
+		}
+	}
+	return false
+}
+
 func generateOperationMessage(installationCondition metav1.Condition, stateInfo StateInfo) string {
 	if stateInfo.Info != "" {
 		return stateInfo.Info
@@ -363,6 +457,31 @@ func generateOperationMessage(installationCondition metav1.Condition, stateInfo
 	return installationCondition.Message
 }
 
+// orphanProtectedResources removes the resources protected by the PruneProtection from the diff, so that they are
+// left in the cluster, and records them in the bounded orphaned resources of the status.
+func (r *Reconciler) orphanProtectedResources(
+	ctx context.Context, clnt Client, obj Object, diff []*resource.Info,
+) ([]*resource.Info, error) {
+	deletable, protected, err := r.PruneProtection.Partition(ctx, clnt, diff)
+	if err != nil {
+		r.Event(obj, "Warning", "PruneProtection", err.Error())
+		obj.SetStatus(obj.GetStatus().WithState(shared.StateError).WithErr(err))
+		return nil, err
+	}
+
+	status := obj.GetStatus()
+	for _, res := range NewInfoToResourceConverter().InfosToResources(protected) {
+		if containsResource(status.Orphaned, res) {
+			continue
+		}
+		r.Event(obj, "Warning", "Orphaned", fmt.Sprintf("resource %s is protected and orphaned instead of pruned",
+			res.ID()))
+		status.Orphaned = recordOrphaned(status.Orphaned, res)
+	}
+	obj.SetStatus(status)
+	return deletable, nil
+}
+
 func (r *Reconciler) deleteDiffResources(
 	ctx context.Context, clnt Client, obj Object, diff []*resource.Info,
 ) error {
@@ -419,7 +538,11 @@ func (r *Reconciler) renderTargetResources(
 		return nil, err
 	}
 
-	for _, transform := range r.PostRenderTransforms {
+	// the scopes of the resources are only known to the target cluster, so its transform is created per cluster.
+	transforms := make([]ObjectTransform, 0, len(r.PostRenderTransforms)+1)
+	transforms = append(transforms, r.PostRenderTransforms...)
+	transforms = append(transforms, targetNamespaceTransform(clnt, clnt.RESTMapper()))
+	for _, transform := range transforms {
 		if err := transform(ctx, obj, targetResources.Items); err != nil {
 			r.Event(obj, "Warning", "PostRenderTransform", err.Error())
 			obj.SetStatus(status.WithState(shared.StateError).WithErr(err))
@@ -444,16 +567,16 @@ func (r *Reconciler) pruneDiff(
 	renderer Renderer,
 	diff []*resource.Info,
 	spec *Spec,
-) error {
+) ([]*resource.Info, error) {
 	var err error
 	diff, err = pruneResource(diff, "Namespace", namespaceNotBeRemoved)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	resourceName := r.ModuleCRDName(obj)
 	diff, err = pruneResource(diff, "CustomResourceDefinition", resourceName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if manifestNotInDeletingAndOciRefNotChangedButDiffDetected(diff, obj, spec) {
@@ -463,22 +586,50 @@ func (r *Reconciler) pruneDiff(
 		r.Event(obj, "Warning", "PruneDiff", ErrResourceSyncDiffInSameOCILayer.Error())
 		obj.SetStatus(obj.GetStatus().WithState(shared.StateWarning).WithOperation(ErrResourceSyncDiffInSameOCILayer.Error()))
 		r.ManifestParser.EvictCache(spec)
-		return ErrResourceSyncDiffInSameOCILayer
+		return nil, ErrResourceSyncDiffInSameOCILayer
+	}
+
+	// protected resources are only orphaned when they are dropped from the manifest, an uninstallation removes them.
+	if obj.GetDeletionTimestamp().IsZero() {
+		diff, err = r.orphanProtectedResources(ctx, clnt, obj, diff)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(diff) > 0 && isPrunePreview(obj) {
+		r.Event(obj, "Normal", "PrunePreview", prunePreviewMessage(diff))
+		return diff, nil
 	}
 
 	if err := r.doPreDelete(ctx, clnt, obj); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := r.deleteDiffResources(ctx, clnt, obj, diff); err != nil {
-		return err
+		return nil, err
 	}
 
 	if obj.GetDeletionTimestamp().IsZero() || !r.DeletePrerequisites {
-		return nil
+		return nil, nil
 	}
 
-	return renderer.RemovePrerequisites(ctx, obj)
+	return nil, renderer.RemovePrerequisites(ctx, obj)
+}
+
+// reportPrunePreview reports the resources held back by a prune preview in a Warning state,
+// so that the object does not become Ready before they are pruned.
+func (r *Reconciler) reportPrunePreview(obj Object, heldBack []*resource.Info) {
+	status := obj.GetStatus()
+	if status.State != shared.StateReady {
+		return
+	}
+	obj.SetStatus(status.WithState(shared.StateWarning).WithOperation(prunePreviewMessage(heldBack)))
+}
+
+func prunePreviewMessage(heldBack []*resource.Info) string {
+	return fmt.Sprintf("%s until the %s annotation is removed: %s", ErrPrunePreview, PrunePreviewAnnotation,
+		resourceIDs(NewInfoToResourceConverter().InfosToResources(heldBack)))
 }
 
 func manifestNotInDeletingAndOciRefNotChangedButDiffDetected(diff []*resource.Info, obj Object, spec *Spec) bool {
@@ -0,0 +1,184 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+)
+
+// resourceErrorLimit bounds the ResourceErrors that are recorded in the status.
+const resourceErrorLimit = 20
+
+// resourceErrorMessageLimit bounds the length of the message of a recorded ResourceError.
+const resourceErrorMessageLimit = 256
+
+var ErrResourcesNotApplied = errors.New("resources could not be applied")
+
+// ResourceApplyError is the error of applying a single resource.
+type ResourceApplyError struct {
+	Resource shared.Resource
+	Err      error
+}
+
+func newResourceApplyError(info *resource.Info, err error) *ResourceApplyError {
+	resources := NewInfoToResourceConverter().InfosToResources([]*resource.Info{info})
+	return &ResourceApplyError{Resource: resources[0], Err: err}
+}
+
+func (e *ResourceApplyError) Error() string {
+	return fmt.Sprintf("patch for %s %s failed: %s", e.Resource.Kind,
+		client.ObjectKey{Namespace: e.Resource.Namespace, Name: e.Resource.Name}, e.Err)
+}
+
+func (e *ResourceApplyError) Unwrap() error {
+	return e.Err
+}
+
+// resourceErrorsOf returns the ResourceErrors of all ResourceApplyErrors in the (joined) error,
+// grouped by their kind in the order the kinds first occur.
+func resourceErrorsOf(err error) []shared.ResourceError {
+	var resourceErrors []shared.ResourceError
+	for _, err := range flatten(err) {
+		var applyErr *ResourceApplyError
+		if !errors.As(err, &applyErr) {
+			continue
+		}
+		resourceErrors = append(resourceErrors, shared.ResourceError{
+			Resource:            applyErr.Resource,
+			ErrorKind:           resourceErrorKindOf(applyErr.Err),
+			Message:             truncate(applyErr.Err.Error(), resourceErrorMessageLimit),
+			ConflictingManagers: conflictingManagersOf(applyErr.Err),
+		})
+	}
+	return groupByKind(resourceErrors)
+}
+
+// limitResourceErrors drops the ResourceErrors beyond the limit of the status.
+func limitResourceErrors(resourceErrors []shared.ResourceError) []shared.ResourceError {
+	if len(resourceErrors) > resourceErrorLimit {
+		return resourceErrors[:resourceErrorLimit]
+	}
+	return resourceErrors
+}
+
+// resourceErrorsByKind groups the ResourceErrors by their kind.
+func resourceErrorsByKind(resourceErrors []shared.ResourceError) map[shared.ResourceErrorKind][]shared.ResourceError {
+	byKind := map[shared.ResourceErrorKind][]shared.ResourceError{}
+	for _, resourceErr := range resourceErrors {
+		byKind[resourceErr.ErrorKind] = append(byKind[resourceErr.ErrorKind], resourceErr)
+	}
+	return byKind
+}
+
+// summarizeResourceErrors describes how many resources failed with which kind of error, instead of the
+// errors of all resources.
+func summarizeResourceErrors(resourceErrors []shared.ResourceError) error {
+	byKind := resourceErrorsByKind(resourceErrors)
+	var kinds []string
+	for _, kind := range kindsInOrder(resourceErrors) {
+		kinds = append(kinds, fmt.Sprintf("%d %s", len(byKind[kind]), kind))
+	}
+	return fmt.Errorf("%w: %d failed (%s), see status.resourceErrors",
+		ErrResourcesNotApplied, len(resourceErrors), strings.Join(kinds, ", "))
+}
+
+// describeResourceErrors describes ResourceErrors of the same kind by the first of them.
+func describeResourceErrors(kind shared.ResourceErrorKind, resourceErrors []shared.ResourceError) string {
+	first := resourceErrors[0]
+	msg := fmt.Sprintf("%d resources failed with %s, e.g. %s %s: %s", len(resourceErrors), kind,
+		first.Kind, client.ObjectKey{Namespace: first.Namespace, Name: first.Name}, first.Message)
+	if len(first.ConflictingManagers) > 0 {
+		msg += fmt.Sprintf(" (conflicting managers: %s)", strings.Join(first.ConflictingManagers, ", "))
+	}
+	return msg
+}
+
+func resourceErrorKindOf(err error) shared.ResourceErrorKind {
+	switch {
+	case meta.IsNoMatchError(err):
+		return shared.ResourceErrorNotFoundMapping
+	case apierrors.IsConflict(err):
+		return shared.ResourceErrorConflict
+	case apierrors.IsForbidden(err):
+		return shared.ResourceErrorForbidden
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return shared.ResourceErrorInvalid
+	default:
+		return shared.ResourceErrorOther
+	}
+}
+
+// conflictingManagersOf returns the field managers from the causes of a server-side apply conflict,
+// which are reported as e.g. `conflict with "kubectl" using apps/v1`.
+func conflictingManagersOf(err error) []string {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil
+	}
+	var managers []string
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		quoted, found := strings.CutPrefix(cause.Message, "conflict with ")
+		if !found {
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(quoted)
+		if err != nil {
+			continue
+		}
+		manager, err := strconv.Unquote(quoted)
+		if err == nil && !slices.Contains(managers, manager) {
+			managers = append(managers, manager)
+		}
+	}
+	return managers
+}
+
+func groupByKind(resourceErrors []shared.ResourceError) []shared.ResourceError {
+	byKind := resourceErrorsByKind(resourceErrors)
+	grouped := make([]shared.ResourceError, 0, len(resourceErrors))
+	for _, kind := range kindsInOrder(resourceErrors) {
+		grouped = append(grouped, byKind[kind]...)
+	}
+	return grouped
+}
+
+func kindsInOrder(resourceErrors []shared.ResourceError) []shared.ResourceErrorKind {
+	var kinds []shared.ResourceErrorKind
+	for _, resourceErr := range resourceErrors {
+		if !slices.Contains(kinds, resourceErr.ErrorKind) {
+			kinds = append(kinds, resourceErr.ErrorKind)
+		}
+	}
+	return kinds
+}
+
+func flatten(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+		var errs []error
+		for _, err := range joined.Unwrap() {
+			errs = append(errs, flatten(err)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+func truncate(msg string, limit int) string {
+	if len(msg) <= limit {
+		return msg
+	}
+	return msg[:limit-3] + "..."
+}
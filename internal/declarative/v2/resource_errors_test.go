@@ -0,0 +1,99 @@
+//nolint:testpackage
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+)
+
+var (
+	errDenied    = errors.New("denied")
+	errSSAFailed = errors.New("ServerSideApply failed")
+)
+
+func applyError(kind, name string, err error) error {
+	return newResourceApplyError(phaseInfo(newResource("v1", kind, "kyma-system", name)), err)
+}
+
+func conflictError(name string, managers ...string) error {
+	causes := make([]metav1.StatusCause, 0, len(managers))
+	for _, manager := range managers {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: fmt.Sprintf("conflict with %q using v1", manager),
+			Field:   ".data.key",
+		})
+	}
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   409,
+		Reason: metav1.StatusReasonConflict,
+		Details: &metav1.StatusDetails{
+			Name:   name,
+			Kind:   "configmaps",
+			Causes: causes,
+		},
+		Message: "Apply failed with conflicts",
+	}}
+}
+
+func Test_resourceErrorsOf(t *testing.T) {
+	t.Parallel()
+
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+	err := errors.Join(
+		applyError("ConfigMap", "forbidden", apierrors.NewForbidden(configMaps, "forbidden", errDenied)),
+		applyError("ConfigMap", "conflict", conflictError("conflict", "kubectl", "other-operator", "kubectl")),
+		applyError("Sample", "sample", &meta.NoKindMatchError{GroupKind: schema.GroupKind{Kind: "Sample"}}),
+		applyError("ConfigMap", "invalid", apierrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "invalid",
+			field.ErrorList{field.Required(field.NewPath("data"), "")})),
+		applyError("ConfigMap", "another-forbidden",
+			apierrors.NewForbidden(configMaps, "another-forbidden", errDenied)),
+		applyError("ConfigMap", "timeout", apierrors.NewTimeoutError("timeout", 1)),
+		errSSAFailed,
+	)
+
+	resourceErrors := resourceErrorsOf(err)
+	require.Len(t, resourceErrors, 6)
+	var names []string
+	var kinds []shared.ResourceErrorKind
+	for _, resourceErr := range resourceErrors {
+		names = append(names, resourceErr.Name)
+		kinds = append(kinds, resourceErr.ErrorKind)
+	}
+	assert.Equal(t, []string{"forbidden", "another-forbidden", "conflict", "sample", "invalid", "timeout"}, names)
+	assert.Equal(t, []shared.ResourceErrorKind{
+		shared.ResourceErrorForbidden, shared.ResourceErrorForbidden, shared.ResourceErrorConflict,
+		shared.ResourceErrorNotFoundMapping, shared.ResourceErrorInvalid, shared.ResourceErrorOther,
+	}, kinds)
+	assert.Equal(t, []string{"kubectl", "other-operator"}, resourceErrors[2].ConflictingManagers)
+
+	require.ErrorIs(t, summarizeResourceErrors(resourceErrors), ErrResourcesNotApplied)
+	assert.Contains(t, summarizeResourceErrors(resourceErrors).Error(),
+		"6 failed (2 Forbidden, 1 Conflict, 1 NotFoundMapping, 1 Invalid, 1 Other)")
+	assert.Contains(t, describeResourceErrors(shared.ResourceErrorConflict, resourceErrors[2:3]),
+		"(conflicting managers: kubectl, other-operator)")
+}
+
+func Test_limitResourceErrors(t *testing.T) {
+	t.Parallel()
+
+	errs := make([]error, 0, resourceErrorLimit+5)
+	for i := 0; i < resourceErrorLimit+5; i++ {
+		errs = append(errs, applyError("ConfigMap", fmt.Sprintf("config-%d", i), conflictError("config")))
+	}
+	resourceErrors := resourceErrorsOf(errors.Join(errs...))
+	require.Len(t, resourceErrors, resourceErrorLimit+5)
+	assert.Len(t, limitResourceErrors(resourceErrors), resourceErrorLimit)
+}
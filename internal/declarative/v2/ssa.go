@@ -4,19 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"k8s.io/apiextensions-apiserver/pkg/apihelpers"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/kyma-project/lifecycle-manager/api/shared"
 	"github.com/kyma-project/lifecycle-manager/internal"
 )
 
-var ErrClientObjectConversionFailed = errors.New("client object conversion failed")
+var (
+	ErrClientObjectConversionFailed = errors.New("client object conversion failed")
+	ErrCRDNotEstablished            = errors.New("CustomResourceDefinitions are not established")
+)
+
+const (
+	crdEstablishedInterval = 500 * time.Millisecond
+	crdEstablishedTimeout  = 10 * time.Second
+)
 
 type SSA interface {
 	Run(ctx context.Context, resourceInfo []*resource.Info) error
@@ -27,6 +42,11 @@ type ConcurrentDefaultSSA struct {
 	owner     client.FieldOwner
 	versioner runtime.GroupVersioner
 	converter runtime.ObjectConvertor
+
+	mu        sync.Mutex
+	conflicts []error
+	// forced are the IDs of the resources whose ownership is forced without detecting conflicts first.
+	forced map[string]bool
 }
 
 func ConcurrentSSA(clnt client.Client, owner client.FieldOwner) *ConcurrentDefaultSSA {
@@ -37,22 +57,30 @@ func ConcurrentSSA(clnt client.Client, owner client.FieldOwner) *ConcurrentDefau
 	}
 }
 
+// Run applies the resources phase by phase, each phase only once the previous phase was applied. Within a phase,
+// the resources are applied concurrently. After the CustomResourceDefinitions are applied, their custom resources
+// are only applied once they are established.
 func (c *ConcurrentDefaultSSA) Run(ctx context.Context, resources []*resource.Info) error {
 	ssaStart := time.Now()
 	logger := log.FromContext(ctx, "owner", c.owner)
 	logger.V(internal.TraceLogLevel).Info("ServerSideApply", "resources", len(resources))
 
-	// The Runtime Complexity of this Branch is N as only ServerSideApplier Patch is required
-	results := make(chan error, len(resources))
-	for i := range resources {
-		i := i
-		go c.serverSideApply(ctx, resources[i], results)
-	}
-
 	var errs []error
-	for i := 0; i < len(resources); i++ {
-		if err := <-results; err != nil {
-			errs = append(errs, err)
+	for phase, infos := range inPhases(resources) {
+		for _, err := range runConcurrently(infos, phaseConcurrencyLimit, func(info *resource.Info) error {
+			return c.serverSideApply(ctx, info)
+		}) {
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if errs == nil && ApplyPhase(phase) == PhaseDefinitions {
+			if err := c.waitForEstablishedCRDs(ctx, infos); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if errs != nil {
+			break
 		}
 	}
 
@@ -69,8 +97,7 @@ func (c *ConcurrentDefaultSSA) Run(ctx context.Context, resources []*resource.In
 func (c *ConcurrentDefaultSSA) serverSideApply(
 	ctx context.Context,
 	resource *resource.Info,
-	results chan error,
-) {
+) error {
 	start := time.Now()
 	logger := log.FromContext(ctx, "owner", c.owner)
 
@@ -81,12 +108,72 @@ func (c *ConcurrentDefaultSSA) serverSideApply(
 		fmt.Sprintf("apply %s", resource.ObjectName()),
 	)
 
-	results <- c.serverSideApplyResourceInfo(ctx, resource)
+	err := c.serverSideApplyResourceInfo(ctx, resource)
 
 	logger.V(internal.TraceLogLevel).Info(
 		fmt.Sprintf("apply %s finished", resource.ObjectName()),
 		"time", time.Since(start),
 	)
+	return err
+}
+
+// waitForEstablishedCRDs waits until the applied CustomResourceDefinitions are established. If the mapper
+// does not know the kinds they define yet, it is reset, so that their custom resources can be applied.
+func (c *ConcurrentDefaultSSA) waitForEstablishedCRDs(ctx context.Context, infos []*resource.Info) error {
+	var names []string
+	for _, info := range infos {
+		if groupKindOf(info) == crdGroupKind {
+			names = append(names, info.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	var crds []*apiextensionsv1.CustomResourceDefinition
+	err := wait.PollUntilContextTimeout(ctx, crdEstablishedInterval, crdEstablishedTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			crds = crds[:0]
+			for _, name := range names {
+				crd, err := c.getCRD(ctx, name)
+				if err != nil {
+					return false, err
+				}
+				if !apihelpers.IsCRDConditionTrue(crd, apiextensionsv1.Established) {
+					return false, nil
+				}
+				crds = append(crds, crd)
+			}
+			return true, nil
+		})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCRDNotEstablished, err)
+	}
+
+	mapper := c.clnt.RESTMapper()
+	for _, crd := range crds {
+		groupKind := schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}
+		if _, err := mapper.RESTMapping(groupKind); meta.IsNoMatchError(err) {
+			meta.MaybeResetRESTMapper(mapper)
+			break
+		}
+	}
+	return nil
+}
+
+func (c *ConcurrentDefaultSSA) getCRD(ctx context.Context, name string) (
+	*apiextensionsv1.CustomResourceDefinition, error,
+) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	if err := c.clnt.Get(ctx, client.ObjectKey{Name: name}, obj); err != nil {
+		return nil, fmt.Errorf("failed to get CustomResourceDefinition %s: %w", name, err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+		return nil, fmt.Errorf("failed to convert CustomResourceDefinition %s: %w", name, err)
+	}
+	return crd, nil
 }
 
 func (c *ConcurrentDefaultSSA) serverSideApplyResourceInfo(
@@ -100,16 +187,50 @@ func (c *ConcurrentDefaultSSA) serverSideApplyResourceInfo(
 		)
 	}
 	obj.SetManagedFields(nil)
-	err := c.clnt.Patch(ctx, obj, client.Apply, client.ForceOwnership, c.owner)
+	if c.forced[NewInfoToResourceConverter().InfosToResources([]*resource.Info{info})[0].ID()] {
+		if err := c.clnt.Patch(ctx, obj, client.Apply, client.ForceOwnership, c.owner); err != nil {
+			return newResourceApplyError(info, err)
+		}
+		return nil
+	}
+	// the resource is applied without forcing first, so that the managers of conflicting fields are reported
+	// by the API server before their fields are taken over.
+	err := c.clnt.Patch(ctx, obj, client.Apply, c.owner)
+	if apierrors.IsConflict(err) && len(conflictingManagersOf(err)) > 0 {
+		c.recordConflict(newResourceApplyError(info, err))
+		err = c.clnt.Patch(ctx, obj, client.Apply, client.ForceOwnership, c.owner)
+	}
 	if err != nil {
-		return fmt.Errorf(
-			"patch for %s failed: %w", info.ObjectName(), err,
-		)
+		return newResourceApplyError(info, err)
 	}
 
 	return nil
 }
 
+// ForceOwnershipOf forces the ownership of the given resources without detecting conflicts first. Their fields
+// were already taken over when they were applied before, so they are neither patched twice nor are the same
+// conflicts reported again whenever they are applied.
+func (c *ConcurrentDefaultSSA) ForceOwnershipOf(resources []shared.Resource) {
+	c.forced = make(map[string]bool, len(resources))
+	for _, res := range resources {
+		c.forced[res.ID()] = true
+	}
+}
+
+// Conflicts returns the conflicts with other field managers of the resources applied by Run as joined
+// ResourceApplyErrors, or nil if there were none. The fields of the other managers were taken over.
+func (c *ConcurrentDefaultSSA) Conflicts() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return errors.Join(c.conflicts...)
+}
+
+func (c *ConcurrentDefaultSSA) recordConflict(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conflicts = append(c.conflicts, err)
+}
+
 // convertWithMapper converts the given object with the optional provided
 // RESTMapping. If no mapping is provided, the default schema versioner is used.
 //
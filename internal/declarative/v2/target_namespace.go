@@ -0,0 +1,213 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+)
+
+// TargetNamespaceAnnotation moves the namespaced resources of an object into the namespace of its value.
+const TargetNamespaceAnnotation = OperatorPrefix + Separator + "target-namespace"
+
+var (
+	ErrTargetNamespaceCollision = errors.New("resources collide in the target namespace")
+	ErrTargetNamespaceOccupied  = errors.New("resources already exist in the target namespace")
+	ErrUnknownResourceScope     = errors.New("scope of resource is unknown")
+)
+
+//nolint:gochecknoglobals
+var (
+	serviceGroupKind        = schema.GroupKind{Group: corev1.GroupName, Kind: "Service"}
+	serviceAccountGroupKind = schema.GroupKind{Group: corev1.GroupName, Kind: "ServiceAccount"}
+)
+
+// targetNamespaceTransform moves the namespaced resources into the namespace of the TargetNamespaceAnnotation
+// of the object, if it has one. Whether a resource is namespaced is determined by the CustomResourceDefinitions
+// among the resources, and otherwise by the mapper of the target cluster. Cluster-scoped resources are left alone,
+// and resources that would end up with the same name in the target namespace are rejected, just like resources
+// that already exist in the target namespace without being synced by the object, so they are not taken over.
+// References to the moved ServiceAccounts and Services are rewritten to the target namespace.
+func targetNamespaceTransform(reader client.Reader, mapper meta.RESTMapper) ObjectTransform {
+	return func(ctx context.Context, obj Object, resources []*unstructured.Unstructured) error {
+		targetNamespace := obj.GetAnnotations()[TargetNamespaceAnnotation]
+		if targetNamespace == "" {
+			return nil
+		}
+		crdScopes := customResourceScopes(resources)
+		synced := syncedResources(obj.GetStatus().Synced)
+		moved := make(map[string]string, len(resources))
+		movedFrom := make(map[string]bool, len(resources))
+		for _, resource := range resources {
+			namespaced, err := isNamespaced(mapper, crdScopes, resource.GroupVersionKind())
+			if err != nil {
+				return err
+			}
+			if !namespaced {
+				continue
+			}
+			gvk := resource.GroupVersionKind()
+			key := fmt.Sprintf("%s/%s", gvk.GroupKind(), resource.GetName())
+			source := fmt.Sprintf("%s/%s", resource.GetNamespace(), resource.GetName())
+			if previous, found := moved[key]; found {
+				return fmt.Errorf("%w %s: %s %s and %s", ErrTargetNamespaceCollision, targetNamespace,
+					gvk.Kind, previous, source)
+			}
+			moved[key] = source
+			targetKey := resourceKey(gvk.GroupKind(), targetNamespace, resource.GetName())
+			if resource.GetNamespace() != targetNamespace && !synced[targetKey] {
+				if err := ensureNotExisting(ctx, reader, gvk, targetNamespace, resource.GetName()); err != nil {
+					return err
+				}
+			}
+			movedFrom[resourceKey(gvk.GroupKind(), resource.GetNamespace(), resource.GetName())] = true
+			resource.SetNamespace(targetNamespace)
+		}
+		for _, resource := range resources {
+			if err := rewriteNamespaceReferences(resource, movedFrom, targetNamespace); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func resourceKey(groupKind schema.GroupKind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", groupKind, namespace, name)
+}
+
+func syncedResources(synced []shared.Resource) map[string]bool {
+	keys := make(map[string]bool, len(synced))
+	for _, resource := range synced {
+		keys[resourceKey(schema.GroupKind{Group: resource.Group, Kind: resource.Kind}, resource.Namespace,
+			resource.Name)] = true
+	}
+	return keys
+}
+
+// ensureNotExisting fails if the resource exists in the namespace. Resources of kinds the cluster does not know yet
+// cannot exist.
+func ensureNotExisting(ctx context.Context, reader client.Reader, gvk schema.GroupVersionKind,
+	namespace, name string,
+) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, existing)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check for %s %s/%s in the target namespace: %w", gvk.Kind, namespace, name, err)
+	}
+	return fmt.Errorf("%w: %s %s/%s", ErrTargetNamespaceOccupied, gvk.Kind, namespace, name)
+}
+
+// rewriteNamespaceReferences points the references of the resource to moved ServiceAccounts and Services
+// to the target namespace: the subjects of (Cluster)RoleBindings, the services of webhook configurations
+// and conversion webhooks, and the service of an APIService.
+func rewriteNamespaceReferences(resource *unstructured.Unstructured, movedFrom map[string]bool,
+	targetNamespace string,
+) error {
+	switch resource.GroupVersionKind().GroupKind() {
+	case schema.GroupKind{Group: rbacv1.GroupName, Kind: "RoleBinding"},
+		schema.GroupKind{Group: rbacv1.GroupName, Kind: "ClusterRoleBinding"}:
+		return rewriteEach(resource.Object, []string{"subjects"}, func(subject map[string]any) error {
+			if subject["kind"] != "ServiceAccount" {
+				return nil
+			}
+			return rewriteReference(subject, serviceAccountGroupKind, movedFrom, targetNamespace)
+		})
+	case schema.GroupKind{Group: admissionregistrationv1.GroupName, Kind: "ValidatingWebhookConfiguration"},
+		schema.GroupKind{Group: admissionregistrationv1.GroupName, Kind: "MutatingWebhookConfiguration"}:
+		return rewriteEach(resource.Object, []string{"webhooks"}, func(webhook map[string]any) error {
+			return rewriteServiceReference(webhook, movedFrom, targetNamespace, "clientConfig", "service")
+		})
+	case crdGroupKind:
+		return rewriteServiceReference(resource.Object, movedFrom, targetNamespace,
+			"spec", "conversion", "webhook", "clientConfig", "service")
+	case schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"}:
+		return rewriteServiceReference(resource.Object, movedFrom, targetNamespace, "spec", "service")
+	}
+	return nil
+}
+
+func rewriteEach(obj map[string]any, fields []string, rewrite func(map[string]any) error) error {
+	items, found, err := unstructured.NestedSlice(obj, fields...)
+	if err != nil || !found {
+		return err //nolint:wrapcheck
+	}
+	for _, item := range items {
+		if itemMap, ok := item.(map[string]any); ok {
+			if err := rewrite(itemMap); err != nil {
+				return err
+			}
+		}
+	}
+	return unstructured.SetNestedSlice(obj, items, fields...) //nolint:wrapcheck
+}
+
+func rewriteServiceReference(obj map[string]any, movedFrom map[string]bool, targetNamespace string,
+	fields ...string,
+) error {
+	service, found, err := unstructured.NestedMap(obj, fields...)
+	if err != nil || !found {
+		return err //nolint:wrapcheck
+	}
+	if err := rewriteReference(service, serviceGroupKind, movedFrom, targetNamespace); err != nil {
+		return err
+	}
+	return unstructured.SetNestedMap(obj, service, fields...) //nolint:wrapcheck
+}
+
+// rewriteReference sets the namespace of a reference with a name and namespace to the target namespace,
+// if the referenced resource was moved.
+func rewriteReference(reference map[string]any, groupKind schema.GroupKind, movedFrom map[string]bool,
+	targetNamespace string,
+) error {
+	namespace, _ := reference["namespace"].(string)
+	name, _ := reference["name"].(string)
+	if !movedFrom[resourceKey(groupKind, namespace, name)] {
+		return nil
+	}
+	return unstructured.SetNestedField(reference, targetNamespace, "namespace") //nolint:wrapcheck
+}
+
+// customResourceScopes returns the scopes of the kinds defined by the CustomResourceDefinitions among the resources,
+// which are not known to the mapper until the definitions are applied.
+func customResourceScopes(resources []*unstructured.Unstructured) map[schema.GroupKind]apiextensionsv1.ResourceScope {
+	scopes := make(map[schema.GroupKind]apiextensionsv1.ResourceScope)
+	for _, resource := range resources {
+		if resource.GroupVersionKind().GroupKind() != apiextensionsv1.Kind("CustomResourceDefinition") {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(resource.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(resource.Object, "spec", "names", "kind")
+		scope, _, _ := unstructured.NestedString(resource.Object, "spec", "scope")
+		scopes[schema.GroupKind{Group: group, Kind: kind}] = apiextensionsv1.ResourceScope(scope)
+	}
+	return scopes
+}
+
+func isNamespaced(mapper meta.RESTMapper, crdScopes map[schema.GroupKind]apiextensionsv1.ResourceScope,
+	gvk schema.GroupVersionKind,
+) (bool, error) {
+	if scope, found := crdScopes[gvk.GroupKind()]; found {
+		return scope == apiextensionsv1.NamespaceScoped, nil
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s: %w", ErrUnknownResourceScope, gvk, err)
+	}
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
@@ -0,0 +1,220 @@
+//nolint:testpackage
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+)
+
+// syncedObj is a testObj with the given resources synced.
+type syncedObj struct {
+	testObj
+	synced []shared.Resource
+}
+
+func (o syncedObj) GetStatus() shared.Status { return shared.Status{Synced: o.synced} }
+
+func newSyncedObj(targetNamespace string, synced ...shared.Resource) syncedObj {
+	obj := syncedObj{testObj: testObj{&unstructured.Unstructured{}}, synced: synced}
+	obj.SetAnnotations(map[string]string{TargetNamespaceAnnotation: targetNamespace})
+	return obj
+}
+
+// newTargetClient creates the client of a target cluster with a ConfigMap named occupied in the custom namespace.
+func newTargetClient() client.Client {
+	occupied := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "custom", Name: "occupied"}}
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(occupied).Build()
+}
+
+func newResource(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(apiVersion)
+	resource.SetKind(kind)
+	resource.SetNamespace(namespace)
+	resource.SetName(name)
+	return resource
+}
+
+func newCRD(group, kind, scope string) *unstructured.Unstructured {
+	crd := newResource("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", kind)
+	crd.Object["spec"] = map[string]any{
+		"group": group,
+		"names": map[string]any{"kind": kind},
+		"scope": scope,
+	}
+	return crd
+}
+
+func Test_targetNamespaceTransform(t *testing.T) {
+	t.Parallel()
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{
+		Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole",
+	}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{
+		Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition",
+	}, meta.RESTScopeRoot)
+
+	tests := []struct {
+		name            string
+		targetNamespace string
+		resources       []*unstructured.Unstructured
+		synced          []shared.Resource
+		wantNamespaces  []string
+		wantErr         error
+	}{
+		{
+			"no target namespace", "",
+			[]*unstructured.Unstructured{newResource("apps/v1", "Deployment", "kyma-system", "operator")},
+			nil, []string{"kyma-system"}, nil,
+		},
+		{
+			"namespaced and cluster-scoped resources", "custom",
+			[]*unstructured.Unstructured{
+				newResource("apps/v1", "Deployment", "kyma-system", "operator"),
+				newResource("v1", "ConfigMap", "", "config"),
+				newResource("rbac.authorization.k8s.io/v1", "ClusterRole", "", "operator"),
+			},
+			nil, []string{"custom", "custom", ""}, nil,
+		},
+		{
+			"kinds defined by the manifest", "custom",
+			[]*unstructured.Unstructured{
+				newCRD("example.io", "Sample", "Namespaced"),
+				newCRD("example.io", "ClusterSample", "Cluster"),
+				newResource("example.io/v1", "Sample", "kyma-system", "sample"),
+				newResource("example.io/v1", "ClusterSample", "", "sample"),
+			},
+			nil, []string{"", "", "custom", ""}, nil,
+		},
+		{
+			"colliding resources", "custom",
+			[]*unstructured.Unstructured{
+				newResource("v1", "ConfigMap", "kyma-system", "config"),
+				newResource("v1", "ConfigMap", "default", "config"),
+			},
+			nil, nil, ErrTargetNamespaceCollision,
+		},
+		{
+			"unknown kind", "custom",
+			[]*unstructured.Unstructured{newResource("example.io/v1", "Unknown", "kyma-system", "unknown")},
+			nil, nil, ErrUnknownResourceScope,
+		},
+		{
+			"resource existing in the target namespace", "custom",
+			[]*unstructured.Unstructured{newResource("v1", "ConfigMap", "kyma-system", "occupied")},
+			nil, nil, ErrTargetNamespaceOccupied,
+		},
+		{
+			"synced resource existing in the target namespace", "custom",
+			[]*unstructured.Unstructured{newResource("v1", "ConfigMap", "kyma-system", "occupied")},
+			[]shared.Resource{{
+				Name: "occupied", Namespace: "custom",
+				GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			}},
+			[]string{"custom"}, nil,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			obj := newSyncedObj(testCase.targetNamespace, testCase.synced...)
+
+			err := targetNamespaceTransform(newTargetClient(), mapper)(context.Background(), obj, testCase.resources)
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(testCase.resources))
+			for _, resource := range testCase.resources {
+				namespaces = append(namespaces, resource.GetNamespace())
+			}
+			assert.Equal(t, testCase.wantNamespaces, namespaces)
+		})
+	}
+}
+
+func Test_targetNamespaceTransform_References(t *testing.T) {
+	t.Parallel()
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ServiceAccount"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{
+		Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding",
+	}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{
+		Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration",
+	}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{
+		Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService",
+	}, meta.RESTScopeRoot)
+
+	binding := newResource("rbac.authorization.k8s.io/v1", "ClusterRoleBinding", "", "operator")
+	binding.Object["subjects"] = []any{
+		map[string]any{"kind": "ServiceAccount", "namespace": "kyma-system", "name": "operator"},
+		map[string]any{"kind": "ServiceAccount", "namespace": "kube-system", "name": "operator"},
+		map[string]any{"kind": "Group", "name": "operators"},
+	}
+	webhooks := newResource("admissionregistration.k8s.io/v1", "ValidatingWebhookConfiguration", "", "operator")
+	webhooks.Object["webhooks"] = []any{
+		map[string]any{"name": "validate.example.io", "clientConfig": map[string]any{
+			"service": map[string]any{"namespace": "kyma-system", "name": "webhook"},
+		}},
+		map[string]any{"name": "external.example.io", "clientConfig": map[string]any{
+			"url": "https://example.io/validate",
+		}},
+	}
+	apiService := newResource("apiregistration.k8s.io/v1", "APIService", "", "v1beta1.metrics.example.io")
+	apiService.Object["spec"] = map[string]any{
+		"service": map[string]any{"namespace": "kyma-system", "name": "webhook"},
+	}
+	resources := []*unstructured.Unstructured{
+		binding, webhooks, apiService,
+		newResource("v1", "ServiceAccount", "kyma-system", "operator"),
+		newResource("v1", "Service", "kyma-system", "webhook"),
+	}
+
+	require.NoError(t, targetNamespaceTransform(newTargetClient(), mapper)(context.Background(),
+		newSyncedObj("custom"), resources))
+
+	subjects, _, err := unstructured.NestedSlice(binding.Object, "subjects")
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"kind": "ServiceAccount", "namespace": "custom", "name": "operator"},
+		map[string]any{"kind": "ServiceAccount", "namespace": "kube-system", "name": "operator"},
+		map[string]any{"kind": "Group", "name": "operators"},
+	}, subjects, "only subjects moved with the module are rewritten")
+	hooks, _, err := unstructured.NestedSlice(webhooks.Object, "webhooks")
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"name": "validate.example.io", "clientConfig": map[string]any{
+			"service": map[string]any{"namespace": "custom", "name": "webhook"},
+		}},
+		map[string]any{"name": "external.example.io", "clientConfig": map[string]any{
+			"url": "https://example.io/validate",
+		}},
+	}, hooks)
+	namespace, _, err := unstructured.NestedString(apiService.Object, "spec", "service", "namespace")
+	require.NoError(t, err)
+	assert.Equal(t, "custom", namespace)
+}
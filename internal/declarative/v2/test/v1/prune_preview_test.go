@@ -0,0 +1,125 @@
+package v1_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/internal"
+
+	. "github.com/kyma-project/lifecycle-manager/internal/declarative/v2"
+	testv1 "github.com/kyma-project/lifecycle-manager/internal/declarative/v2/test/v1"
+)
+
+var _ = Describe("Test Manifest Reconciliation with a prune preview", Ordered, func() {
+	const previewConfigMap = "prune-preview-config"
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var reconciler *Reconciler
+	var env *envtest.Environment
+	var cfg *rest.Config
+	var testClient client.Client
+
+	runID := fmt.Sprintf("run-%s", rand.String(4))
+	obj := &testv1.TestAPI{Spec: testv1.TestAPISpec{ManifestName: "prune-preview-manifest"}}
+	obj.SetLabels(labels.Set{testRunLabel: runID})
+	obj.SetNamespace(customResourceNamespace.Name)
+	obj.SetName(runID)
+
+	key := client.ObjectKeyFromObject(obj)
+
+	oldDeployedResources, err := internal.ParseManifestToObjects(path.Join(testSamplesDir, "raw-manifest.yaml"))
+	Expect(err).NotTo(HaveOccurred())
+
+	setPrunePreview := func(preview bool) {
+		current := &testv1.TestAPI{}
+		Expect(testClient.Get(ctx, key, current)).To(Succeed())
+		patch := client.MergeFrom(current.DeepCopy())
+		annotations := current.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		if preview {
+			annotations[PrunePreviewAnnotation] = "true"
+		} else {
+			delete(annotations, PrunePreviewAnnotation)
+		}
+		current.SetAnnotations(annotations)
+		Expect(testClient.Patch(ctx, current, patch)).To(Succeed())
+		// the reconciler must see the annotation before the spec changes, or it would prune right away.
+		Eventually(func(g Gomega) {
+			cached := &testv1.TestAPI{}
+			g.Expect(reconciler.Get(ctx, key, cached)).To(Succeed())
+			g.Expect(cached.GetAnnotations()[PrunePreviewAnnotation] == "true").To(Equal(preview))
+		}, standardTimeout, standardInterval).Should(Succeed())
+	}
+
+	BeforeAll(func() {
+		env, cfg = StartEnv()
+		testClient = GetTestClient(cfg)
+		ctx, cancel = context.WithCancel(context.TODO())
+		reconciler = StartDeclarativeReconcilerForRun(ctx, runID, cfg,
+			WithRemoteTargetCluster(func(context.Context, Object) (*ClusterInfo, error) {
+				return &ClusterInfo{Config: cfg}, nil
+			}),
+			WithSpecResolver(DefaultSpec(filepath.Join(testSamplesDir, "raw-manifest.yaml"), "sha256:synced",
+				RenderModeRaw)),
+		)
+	})
+
+	It("Should create manifest resources", func() {
+		Expect(testClient.Create(ctx, obj)).To(Succeed())
+
+		EventuallyDeclarativeStatusShould(ctx, key, testClient, BeInState(shared.StateReady))
+	})
+
+	It("Should apply a new version but hold back pruning while the preview is annotated", func() {
+		setPrunePreview(true)
+		manifestPath := filepath.Join(testDir, "prune-preview-manifest.yaml")
+		Expect(os.WriteFile(manifestPath, []byte(fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: %s
+`, previewConfigMap, customResourceNamespace.Name)), 0o600)).To(Succeed())
+		reconciler.SpecResolver = DefaultSpec(manifestPath, "sha256:preview", RenderModeRaw)
+
+		Eventually(func() error {
+			return testClient.Get(ctx, client.ObjectKey{
+				Namespace: customResourceNamespace.Name, Name: previewConfigMap,
+			}, &v1.ConfigMap{})
+		}, standardTimeout, standardInterval).Should(Succeed(), "the new version is applied")
+		EventuallyDeclarativeStatusShould(ctx, key, testClient, BeInState(shared.StateWarning))
+		Expect(StatusOnCluster(ctx, key, testClient).LastOperation.Operation).
+			To(ContainSubstring(ErrPrunePreview.Error()))
+		Expect(validateOldResourcesNotLongerDeployed(ctx, oldDeployedResources, testClient)).
+			To(MatchError(ErrOldResourcesStillDeployed))
+	})
+
+	It("Should prune the resources once the preview annotation is removed", func() {
+		setPrunePreview(false)
+
+		Eventually(validateOldResourcesNotLongerDeployed, standardTimeout, standardInterval).
+			WithContext(ctx).
+			WithArguments(ctx, oldDeployedResources, testClient).
+			Should(Succeed())
+		EventuallyDeclarativeStatusShould(ctx, key, testClient, BeInState(shared.StateReady))
+	})
+
+	AfterAll(func() {
+		cancel()
+		Expect(env.Stop()).To(Succeed())
+	})
+})
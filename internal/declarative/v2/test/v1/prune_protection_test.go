@@ -0,0 +1,88 @@
+package v1_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/pkg/util"
+
+	. "github.com/kyma-project/lifecycle-manager/internal/declarative/v2"
+	testv1 "github.com/kyma-project/lifecycle-manager/internal/declarative/v2/test/v1"
+)
+
+var _ = Describe("Test Manifest Reconciliation for the uninstallation of prune protected resources", Ordered, func() {
+	const protectedConfigMap = "protected-config"
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var env *envtest.Environment
+	var cfg *rest.Config
+	var testClient client.Client
+
+	runID := fmt.Sprintf("run-%s", rand.String(4))
+	obj := &testv1.TestAPI{Spec: testv1.TestAPISpec{ManifestName: "prune-protection-manifest"}}
+	obj.SetLabels(labels.Set{testRunLabel: runID})
+	obj.SetNamespace(customResourceNamespace.Name)
+	obj.SetName(runID)
+
+	key := client.ObjectKeyFromObject(obj)
+	configMapKey := client.ObjectKey{Namespace: customResourceNamespace.Name, Name: protectedConfigMap}
+
+	BeforeAll(func() {
+		env, cfg = StartEnv()
+		testClient = GetTestClient(cfg)
+		ctx, cancel = context.WithCancel(context.TODO())
+
+		manifestPath := filepath.Join(testDir, "prune-protection-manifest.yaml")
+		Expect(os.WriteFile(manifestPath, []byte(fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: %s
+  labels:
+    protected: "true"
+`, protectedConfigMap, customResourceNamespace.Name)), 0o600)).To(Succeed())
+		protection, err := ParsePruneProtection("protected=true", "")
+		Expect(err).NotTo(HaveOccurred())
+		StartDeclarativeReconcilerForRun(ctx, runID, cfg,
+			WithRemoteTargetCluster(func(context.Context, Object) (*ClusterInfo, error) {
+				return &ClusterInfo{Config: cfg}, nil
+			}),
+			WithSpecResolver(DefaultSpec(manifestPath, "sha256:synced", RenderModeRaw)),
+			WithPruneProtection(protection),
+		)
+	})
+
+	It("Should create manifest resources", func() {
+		Expect(testClient.Create(ctx, obj)).To(Succeed())
+
+		EventuallyDeclarativeStatusShould(ctx, key, testClient, BeInState(shared.StateReady))
+		Expect(testClient.Get(ctx, configMapKey, &v1.ConfigMap{})).To(Succeed())
+	})
+
+	It("Should delete protected resources when the module is uninstalled", func() {
+		Expect(testClient.Get(ctx, key, obj)).To(Succeed())
+		Expect(testClient.Delete(ctx, obj)).To(Succeed())
+
+		EventuallyDeclarativeShouldBeUninstalled(ctx, obj, testClient)
+		Eventually(func() error {
+			return testClient.Get(ctx, configMapKey, &v1.ConfigMap{})
+		}, standardTimeout, standardInterval).Should(Satisfy(util.IsNotFound), "protected resources are not orphaned")
+	})
+
+	AfterAll(func() {
+		cancel()
+		Expect(env.Stop()).To(Succeed())
+	})
+})
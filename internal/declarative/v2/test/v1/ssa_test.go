@@ -0,0 +1,109 @@
+package v1_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+
+	. "github.com/kyma-project/lifecycle-manager/internal/declarative/v2"
+)
+
+var _ = Describe("Server-side apply of resources managed by other field managers", Ordered, func() {
+	const (
+		otherManager = "other-operator"
+		owner        = client.FieldOwner("declarative.kyma-project.io/applier")
+	)
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var env *envtest.Environment
+	var testClient client.Client
+
+	configMap := func(value string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace(customResourceNamespace.Name)
+		obj.SetName("conflicting-config")
+		Expect(unstructured.SetNestedField(obj.Object, value, "data", "key")).To(Succeed())
+		return obj
+	}
+	apply := func(forced ...shared.Resource) *ConcurrentDefaultSSA {
+		obj := configMap("desired")
+		ssa := ConcurrentSSA(testClient, owner)
+		ssa.ForceOwnershipOf(forced)
+		Expect(ssa.Run(ctx, []*resource.Info{
+			{Namespace: obj.GetNamespace(), Name: obj.GetName(), Object: obj},
+		})).To(Succeed())
+		return ssa
+	}
+	getValue := func() string {
+		applied := &v1.ConfigMap{}
+		Expect(testClient.Get(ctx, client.ObjectKey{
+			Namespace: customResourceNamespace.Name, Name: "conflicting-config",
+		}, applied)).To(Succeed())
+		return applied.Data["key"]
+	}
+
+	BeforeAll(func() {
+		var cfg *rest.Config
+		env, cfg = StartEnv()
+		testClient = GetTestClient(cfg)
+		ctx, cancel = context.WithCancel(context.TODO())
+	})
+
+	It("Should report the other field manager and take over its fields", func() {
+		Expect(testClient.Patch(ctx, configMap("other"), client.Apply, client.FieldOwner(otherManager))).
+			To(Succeed())
+
+		conflicts := apply().Conflicts()
+
+		var applyErr *ResourceApplyError
+		Expect(errors.As(conflicts, &applyErr)).To(BeTrue())
+		Expect(applyErr.Resource.Name).To(Equal("conflicting-config"))
+		Expect(apierrors.IsConflict(applyErr.Err)).To(BeTrue())
+		Expect(applyErr.Err.Error()).To(ContainSubstring(otherManager))
+
+		applied := &v1.ConfigMap{}
+		Expect(testClient.Get(ctx, client.ObjectKey{
+			Namespace: customResourceNamespace.Name, Name: "conflicting-config",
+		}, applied)).To(Succeed())
+		Expect(applied.Data).To(HaveKeyWithValue("key", "desired"))
+		Expect(applied.GetManagedFields()).To(ContainElement(
+			HaveField("Manager", string(owner)),
+		))
+	})
+
+	It("Should not report conflicts once the fields are taken over", func() {
+		Expect(apply().Conflicts()).ToNot(HaveOccurred())
+	})
+
+	It("Should take over the fields of synced resources without reporting conflicts", func() {
+		Expect(testClient.Patch(ctx, configMap("other"), client.Apply, client.FieldOwner(otherManager),
+			client.ForceOwnership)).To(Succeed())
+		Expect(getValue()).To(Equal("other"))
+
+		synced := shared.Resource{
+			Namespace: customResourceNamespace.Name, Name: "conflicting-config",
+			GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		}
+		Expect(apply(synced).Conflicts()).ToNot(HaveOccurred())
+		Expect(getValue()).To(Equal("desired"))
+	})
+
+	AfterAll(func() {
+		cancel()
+		Expect(env.Stop()).To(Succeed())
+	})
+})
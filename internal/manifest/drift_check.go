@@ -0,0 +1,120 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/util"
+
+	declarative "github.com/kyma-project/lifecycle-manager/internal/declarative/v2"
+)
+
+type DriftDetectionMode string
+
+const (
+	// DriftDetectionDisabled does not compare the module CR with the Manifest.
+	DriftDetectionDisabled DriftDetectionMode = "disabled"
+	// DriftDetectionReport reports drifted fields of the module CR in the Manifest.
+	DriftDetectionReport DriftDetectionMode = "report"
+	// DriftDetectionCorrect reports drifted fields of the module CR and re-applies their desired state.
+	DriftDetectionCorrect DriftDetectionMode = "correct"
+)
+
+var ErrInvalidDriftDetectionMode = errors.New("invalid drift detection mode")
+
+// ParseDriftDetectionMode parses the given mode, so that a misspelled mode fails at startup
+// instead of silently reporting drift.
+func ParseDriftDetectionMode(mode string) (DriftDetectionMode, error) {
+	switch parsed := DriftDetectionMode(mode); parsed {
+	case DriftDetectionDisabled, DriftDetectionReport, DriftDetectionCorrect:
+		return parsed, nil
+	default:
+		return "", fmt.Errorf("%w %q: must be one of %s, %s or %s", ErrInvalidDriftDetectionMode, mode,
+			DriftDetectionDisabled, DriftDetectionReport, DriftDetectionCorrect)
+	}
+}
+
+// NewCustomResourceDriftCheck creates a check that compares the module CR in the remote cluster with the
+// Resource in the Manifest. Only fields set in the Resource are compared, so that fields defaulted
+// or added by the module are not considered as drift.
+func NewCustomResourceDriftCheck(mode DriftDetectionMode) *CustomResourceDriftCheck {
+	return &CustomResourceDriftCheck{correct: mode == DriftDetectionCorrect}
+}
+
+type CustomResourceDriftCheck struct {
+	correct bool
+}
+
+func (c *CustomResourceDriftCheck) Run(ctx context.Context,
+	clnt declarative.Client,
+	obj declarative.Object,
+) (*declarative.DriftInfo, error) {
+	manifest, ok := obj.(*v1beta2.Manifest)
+	if !ok {
+		return nil, v1beta2.ErrTypeAssertManifest
+	}
+	if manifest.Spec.Resource == nil {
+		return nil, nil
+	}
+
+	desired := manifest.Spec.Resource.DeepCopy()
+	live := desired.DeepCopy()
+	if err := clnt.Get(ctx, client.ObjectKeyFromObject(live), live); err != nil {
+		if util.IsNotFound(err) {
+			// the module CR is (re-)created by PostRunCreateCR.
+			return &declarative.DriftInfo{}, nil
+		}
+		return nil, fmt.Errorf("failed to fetch resource for drift detection: %w", err)
+	}
+
+	drift := &declarative.DriftInfo{}
+	for _, key := range sortedKeys(desired.Object) {
+		switch key {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		drift.Fields = append(drift.Fields, diffFields(key, desired.Object[key], live.Object[key])...)
+	}
+
+	if c.correct && len(drift.Fields) > 0 {
+		if err := clnt.Patch(ctx, desired, client.Apply, client.ForceOwnership,
+			client.FieldOwner(declarative.CustomResourceManager)); err != nil {
+			return drift, fmt.Errorf("failed to re-apply drifted resource: %w", err)
+		}
+		drift.Corrected = true
+	}
+	return drift, nil
+}
+
+// diffFields describes every field of desired that differs in live.
+func diffFields(path string, desired, live any) []string {
+	desiredMap, isMap := desired.(map[string]any)
+	liveMap, liveIsMap := live.(map[string]any)
+	if !isMap || !liveIsMap {
+		if equality.Semantic.DeepEqual(desired, live) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: expected %v, found %v", path, desired, live)}
+	}
+
+	var drift []string
+	for _, key := range sortedKeys(desiredMap) {
+		drift = append(drift, diffFields(path+"."+key, desiredMap[key], liveMap[key])...)
+	}
+	return drift
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,114 @@
+package manifest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	v2 "github.com/kyma-project/lifecycle-manager/internal/declarative/v2"
+	"github.com/kyma-project/lifecycle-manager/internal/manifest"
+)
+
+// fakeDeclarativeClient only serves Get for the given module CR.
+type fakeDeclarativeClient struct {
+	resource.RESTClientGetter
+	v2.ResourceInfoConverter
+	client.Client
+	moduleCR *unstructured.Unstructured
+}
+
+func (c *fakeDeclarativeClient) Get(_ context.Context, key client.ObjectKey, obj client.Object,
+	_ ...client.GetOption,
+) error {
+	if c.moduleCR == nil || key != client.ObjectKeyFromObject(c.moduleCR) {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "samples"}, key.Name)
+	}
+	c.moduleCR.DeepCopyInto(obj.(*unstructured.Unstructured)) //nolint:forcetypeassert
+	return nil
+}
+
+func newModuleCR(spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "operator.kyma-project.io/v1alpha1",
+		"kind":       "Sample",
+		"metadata":   map[string]any{"name": "sample", "namespace": "kyma-system"},
+		"spec":       spec,
+	}}
+}
+
+func TestCustomResourceDriftCheck_Run(t *testing.T) {
+	t.Parallel()
+	desired := newModuleCR(map[string]any{
+		"replicas": int64(1),
+		"config":   map[string]any{"level": "info"},
+	})
+	live := newModuleCR(map[string]any{
+		"replicas":  int64(2),
+		"config":    map[string]any{"level": "info", "format": "json"},
+		"defaulted": true,
+	})
+	manifestCR := &v1beta2.Manifest{Spec: v1beta2.ManifestSpec{Resource: desired}}
+	clnt := &fakeDeclarativeClient{moduleCR: live}
+
+	drift, err := manifest.NewCustomResourceDriftCheck(manifest.DriftDetectionReport).
+		Run(context.Background(), clnt, manifestCR)
+
+	require.NoError(t, err)
+	require.NotNil(t, drift)
+	assert.Equal(t, []string{"spec.replicas: expected 1, found 2"}, drift.Fields)
+	assert.False(t, drift.Corrected)
+}
+
+func TestCustomResourceDriftCheck_RunWithoutResource(t *testing.T) {
+	t.Parallel()
+	clnt := &fakeDeclarativeClient{}
+	check := manifest.NewCustomResourceDriftCheck(manifest.DriftDetectionReport)
+
+	drift, err := check.Run(context.Background(), clnt, &v1beta2.Manifest{})
+	require.NoError(t, err)
+	assert.Nil(t, drift)
+
+	manifestCR := &v1beta2.Manifest{Spec: v1beta2.ManifestSpec{Resource: newModuleCR(map[string]any{})}}
+	drift, err = check.Run(context.Background(), clnt, manifestCR)
+	require.NoError(t, err)
+	assert.Empty(t, drift.Fields, "a missing module CR is not reported as drift")
+}
+
+func TestParseDriftDetectionMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mode    string
+		want    manifest.DriftDetectionMode
+		wantErr bool
+	}{
+		{mode: "disabled", want: manifest.DriftDetectionDisabled},
+		{mode: "report", want: manifest.DriftDetectionReport},
+		{mode: "correct", want: manifest.DriftDetectionCorrect},
+		{mode: "Correct", wantErr: true},
+		{mode: "", wantErr: true},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.mode, func(t *testing.T) {
+			t.Parallel()
+
+			mode, err := manifest.ParseDriftDetectionMode(testCase.mode)
+
+			if testCase.wantErr {
+				require.ErrorIs(t, err, manifest.ErrInvalidDriftDetectionMode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, mode)
+		})
+	}
+}
@@ -8,13 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/cel-go/cel"
+	"github.com/jellydator/ttlcache/v3"
 	"github.com/kyma-project/lifecycle-manager/api/shared"
 	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
-	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/cli-runtime/pkg/resource"
-	deploymentutil "k8s.io/kubectl/pkg/util/deployment"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	declarative "github.com/kyma-project/lifecycle-manager/internal/declarative/v2"
@@ -25,16 +24,25 @@ const (
 	customResourceStatePath          = "status.state"
 	ModuleCRWithCustomCheckWarning   = "module CR state not found or given customStateCheck.jsonPath is not exists"
 	ModuleCRWithNoCustomCheckWarning = "module CR state not found"
+	// stateCheckProgramLimit bounds the number of compiled state check expressions kept in memory.
+	stateCheckProgramLimit = 256
 )
 
-// NewCustomResourceReadyCheck creates a readiness check that verifies that the Resource in the Manifest
-// returns the ready state, if not it returns not ready.
+// NewCustomResourceReadyCheck creates a readiness check that verifies that all rendered resources with
+// a readiness rule are ready, and that the Resource in the Manifest returns the ready state.
+// If not, it returns not ready and names the first resource that is not ready.
 func NewCustomResourceReadyCheck() *CustomResourceReadyCheck {
 	return &CustomResourceReadyCheck{}
 }
 
 type CustomResourceReadyCheck struct{}
 
+// stateCheckPrograms caches the compiled programs of state check expressions by their expression.
+// The least recently used programs are evicted, so that expressions of removed templates are not kept forever.
+var stateCheckPrograms = ttlcache.New[string, cel.Program]( //nolint:gochecknoglobals
+	ttlcache.WithCapacity[string, cel.Program](stateCheckProgramLimit),
+)
+
 var (
 	ErrNotSupportedState    = errors.New("module CR state not support")
 	ErrRequiredStateMissing = errors.New("required Ready and Error state mapping are missing")
@@ -45,10 +53,16 @@ func (c *CustomResourceReadyCheck) Run(ctx context.Context,
 	obj declarative.Object,
 	resources []*resource.Info,
 ) (declarative.StateInfo, error) {
-	if !isDeploymentReady(clnt, resources) {
+	readiness, err := EvaluateReadiness(resources)
+	if err != nil {
+		return declarative.StateInfo{State: shared.StateError}, err
+	}
+	if unready, found := FirstUnready(readiness); found {
 		return declarative.StateInfo{
 			State: shared.StateProcessing,
-			Info:  "module operator deployment is not ready",
+			Info: fmt.Sprintf("%s %s is not ready: %s", unready.Kind,
+				client.ObjectKey{Namespace: unready.Namespace, Name: unready.Name}, unready.Message),
+			Readiness: readiness,
 		}, nil
 	}
 	manifest, ok := obj.(*v1beta2.Manifest)
@@ -56,13 +70,15 @@ func (c *CustomResourceReadyCheck) Run(ctx context.Context,
 		return declarative.StateInfo{State: shared.StateError}, v1beta2.ErrTypeAssertManifest
 	}
 	if manifest.Spec.Resource == nil {
-		return declarative.StateInfo{State: shared.StateReady}, nil
+		return declarative.StateInfo{State: shared.StateReady, Readiness: readiness}, nil
 	}
 	moduleCR := manifest.Spec.Resource.DeepCopy()
 	if err := clnt.Get(ctx, client.ObjectKeyFromObject(moduleCR), moduleCR); err != nil {
 		return declarative.StateInfo{State: shared.StateError}, fmt.Errorf("failed to fetch resource: %w", err)
 	}
-	return HandleState(manifest, moduleCR)
+	stateInfo, err := HandleState(manifest, moduleCR)
+	stateInfo.Readiness = readiness
+	return stateInfo, err
 }
 
 func HandleState(manifest *v1beta2.Manifest, moduleCR *unstructured.Unstructured) (declarative.StateInfo, error) {
@@ -115,29 +131,95 @@ func mappingState(stateChecks []*v1beta2.CustomStateCheck,
 	stateResult := map[shared.State]bool{}
 	foundStateInCR := false
 	for _, stateCheck := range stateChecks {
-		stateFromCR, stateExists, err := unstructured.NestedString(moduleCR.Object,
-			strings.Split(stateCheck.JSONPath, ".")...)
+		matched, stateExists, err := matchStateCheck(stateCheck, moduleCR, customStateFound)
 		if err != nil {
-			return "", false, fmt.Errorf("could not get state from module CR %s at path %s "+
-				"to determine readiness: %w", moduleCR.GetName(), stateCheck.JSONPath, err)
+			return "", false, err
 		}
 		if !stateExists {
 			continue
 		}
-		if !customStateFound && !shared.State(stateFromCR).IsSupportedState() {
-			return "", false, ErrNotSupportedState
-		}
 		foundStateInCR = true
 		_, found := stateResult[stateCheck.MappedState]
 		if found {
-			stateResult[stateCheck.MappedState] = stateResult[stateCheck.MappedState] && stateFromCR == stateCheck.Value
+			stateResult[stateCheck.MappedState] = stateResult[stateCheck.MappedState] && matched
 		} else {
-			stateResult[stateCheck.MappedState] = stateFromCR == stateCheck.Value
+			stateResult[stateCheck.MappedState] = matched
 		}
 	}
 	return calculateFinalState(stateResult), foundStateInCR, nil
 }
 
+// matchStateCheck returns whether the module CR matches the state check, and whether the state that the check
+// refers to exists in the module CR.
+func matchStateCheck(stateCheck *v1beta2.CustomStateCheck,
+	moduleCR *unstructured.Unstructured,
+	customStateFound bool,
+) (bool, bool, error) {
+	if stateCheck.Expression != "" {
+		return evaluateStateCheckExpression(stateCheck.Expression, moduleCR)
+	}
+	stateFromCR, stateExists, err := unstructured.NestedString(moduleCR.Object,
+		strings.Split(stateCheck.JSONPath, ".")...)
+	if err != nil {
+		return false, false, fmt.Errorf("could not get state from module CR %s at path %s "+
+			"to determine readiness: %w", moduleCR.GetName(), stateCheck.JSONPath, err)
+	}
+	if !stateExists {
+		return false, false, nil
+	}
+	if !customStateFound && !shared.State(stateFromCR).IsSupportedState() {
+		return false, false, ErrNotSupportedState
+	}
+	return stateFromCR == stateCheck.Value, true, nil
+}
+
+// evaluateStateCheckExpression evaluates the CEL expression of a state check against the module CR.
+// If the expression refers to a field the module CR does not have yet, the state is considered as not existing.
+// Other evaluation errors, e.g. comparing values of different types, are returned.
+func evaluateStateCheckExpression(expression string, moduleCR *unstructured.Unstructured) (bool, bool, error) {
+	program, err := compileStateCheckExpression(expression)
+	if err != nil {
+		return false, false, err
+	}
+	result, _, err := program.Eval(map[string]any{v1beta2.StateCheckExpressionVariable: moduleCR.Object})
+	if isMissingFieldError(err) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("%w: expression %q failed for module CR %s: %w",
+			ErrInvalidStateCheckExpression, expression, moduleCR.GetName(), err)
+	}
+	matched, ok := result.Value().(bool)
+	if !ok {
+		return false, false, fmt.Errorf("%w: expression %q evaluates to %s instead of bool",
+			ErrInvalidStateCheckExpression, expression, result.Type().TypeName())
+	}
+	return matched, true, nil
+}
+
+// isMissingFieldError returns whether the evaluation of an expression failed because it selects a field
+// or key that does not exist in the module CR. CEL does not offer typed errors, so its messages are matched.
+func isMissingFieldError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.HasPrefix(msg, "no such key") || strings.HasPrefix(msg, "no such attribute")
+}
+
+// compileStateCheckExpression compiles the expression once and reuses the program for later evaluations.
+func compileStateCheckExpression(expression string) (cel.Program, error) {
+	if item := stateCheckPrograms.Get(expression); item != nil {
+		return item.Value(), nil
+	}
+	program, err := CompileStateCheckExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile state check: %w", err)
+	}
+	stateCheckPrograms.Set(expression, program, ttlcache.NoTTL)
+	return program, nil
+}
+
 func calculateFinalState(stateResult map[shared.State]bool) shared.State {
 	if stateResult[shared.StateError] {
 		return shared.StateError
@@ -202,27 +284,3 @@ func parseStateChecks(manifest *v1beta2.Manifest) ([]*v1beta2.CustomStateCheck,
 	}
 	return stateCheck, true, nil
 }
-
-func isDeploymentReady(clt declarative.Client, resources []*resource.Info) bool {
-	deploy := &appsv1.Deployment{}
-	found := false
-	for _, res := range resources {
-		err := clt.Scheme().Convert(res.Object, deploy, nil)
-		if err == nil {
-			found = true
-			break
-		}
-	}
-	// not every module operator use Deployment by default, e.g: StatefulSet also a valid approach
-	if !found {
-		return true
-	}
-	availableCond := deploymentutil.GetDeploymentCondition(deploy.Status, appsv1.DeploymentAvailable)
-	if availableCond != nil && availableCond.Status == corev1.ConditionTrue {
-		return true
-	}
-	if deploy.Spec.Replicas != nil && *deploy.Spec.Replicas == deploy.Status.ReadyReplicas {
-		return true
-	}
-	return false
-}
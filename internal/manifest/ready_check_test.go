@@ -12,6 +12,8 @@ import (
 	"github.com/kyma-project/lifecycle-manager/internal/manifest"
 	"github.com/kyma-project/lifecycle-manager/pkg/testutils"
 	"github.com/kyma-project/lifecycle-manager/pkg/testutils/builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -443,3 +445,111 @@ func TestHandleStateWithDuration(t *testing.T) {
 		})
 	}
 }
+
+//nolint:funlen
+func TestHandleState_Expression(t *testing.T) {
+	t.Parallel()
+	installed := func(status string) map[string]any {
+		return map[string]any{
+			"replicas": int64(3),
+			"conditions": []any{
+				map[string]any{"type": "Installed", "status": status},
+			},
+		}
+	}
+	readyExpression := `self.status.conditions.exists(c, c.type == "Installed" && c.status == "True")`
+	errorExpression := `self.status.conditions.exists(c, c.type == "Installed" && c.status == "False")`
+	tests := []struct {
+		name        string
+		customState []*v1beta2.CustomStateCheck
+		status      map[string]any
+		want        v2.StateInfo
+		wantErr     bool
+	}{
+		{
+			"condition matched by expression, expected mapped to StateReady",
+			[]*v1beta2.CustomStateCheck{
+				{Expression: readyExpression, MappedState: shared.StateReady},
+				{Expression: errorExpression, MappedState: shared.StateError},
+			},
+			installed("True"),
+			v2.StateInfo{State: shared.StateReady},
+			false,
+		},
+		{
+			"condition matched by expression, expected mapped to StateError",
+			[]*v1beta2.CustomStateCheck{
+				{Expression: readyExpression, MappedState: shared.StateReady},
+				{Expression: errorExpression, MappedState: shared.StateError},
+			},
+			installed("False"),
+			v2.StateInfo{State: shared.StateError},
+			false,
+		},
+		{
+			"expression combined with JSONPath, expected mapped to StateProcessing when not all condition matched",
+			[]*v1beta2.CustomStateCheck{
+				{Expression: readyExpression, MappedState: shared.StateReady},
+				{Expression: "self.status.replicas >= 5", MappedState: shared.StateReady},
+				{JSONPath: "status.health", Value: "red", MappedState: shared.StateError},
+			},
+			installed("True"),
+			v2.StateInfo{State: shared.StateProcessing},
+			false,
+		},
+		{
+			"expression referring to missing fields, expected state not found",
+			[]*v1beta2.CustomStateCheck{
+				{Expression: readyExpression, MappedState: shared.StateReady},
+				{Expression: errorExpression, MappedState: shared.StateError},
+			},
+			nil,
+			v2.StateInfo{State: shared.StateProcessing, Info: manifest.ModuleCRWithCustomCheckWarning},
+			false,
+		},
+		{
+			"expression failing for an existing field, expected StateError with error",
+			[]*v1beta2.CustomStateCheck{
+				{Expression: `self.status.replicas > "3"`, MappedState: shared.StateReady},
+				{Expression: errorExpression, MappedState: shared.StateError},
+			},
+			installed("True"),
+			v2.StateInfo{State: shared.StateError},
+			true,
+		},
+		{
+			"expression not evaluating to bool, expected StateError with error",
+			[]*v1beta2.CustomStateCheck{
+				{Expression: "self.status.replicas", MappedState: shared.StateReady},
+				{Expression: errorExpression, MappedState: shared.StateError},
+			},
+			installed("True"),
+			v2.StateInfo{State: shared.StateError},
+			true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			manifestCR := testutils.NewTestManifest("test")
+			marshal, err := json.Marshal(testCase.customState)
+			require.NoError(t, err)
+			manifestCR.Annotations[v1beta2.CustomStateCheckAnnotation] = string(marshal)
+			manifestCR.CreationTimestamp = v1.Now()
+			moduleCR := builder.NewModuleCRBuilder().WithName("test").WithNamespace(v1.NamespaceDefault).
+				WithGroupVersionKind(v1beta2.GroupVersion.Group, "v1", "TestCR").Build()
+			if testCase.status != nil {
+				require.NoError(t, unstructured.SetNestedMap(moduleCR.Object, testCase.status, "status"))
+			}
+
+			got, err := manifest.HandleState(manifestCR, moduleCR)
+			if testCase.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package manifest
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+)
+
+// readinessRule determines whether a resource is ready from its state in the cluster. If it is not ready,
+// the rule explains why.
+type readinessRule func(obj map[string]any) (bool, string, error)
+
+var readinessRules = map[schema.GroupKind]readinessRule{ //nolint:gochecknoglobals
+	{Group: "apps", Kind: "Deployment"}:                               deploymentReadiness,
+	{Group: "apps", Kind: "StatefulSet"}:                              statefulSetReadiness,
+	{Group: "apps", Kind: "DaemonSet"}:                                daemonSetReadiness,
+	{Group: "batch", Kind: "Job"}:                                     jobReadiness,
+	{Kind: "PersistentVolumeClaim"}:                                   persistentVolumeClaimReadiness,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: conditionReadiness("Established"),
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:             conditionReadiness("Available"),
+}
+
+// EvaluateReadiness evaluates the readiness of all resources with a readiness rule, in the order of the resources.
+// The resources are expected to hold their state in the cluster, e.g. as returned by the apply.
+func EvaluateReadiness(resources []*resource.Info) ([]shared.ResourceReadiness, error) {
+	var readiness []shared.ResourceReadiness
+	for _, info := range resources {
+		gvk := info.Object.GetObjectKind().GroupVersionKind()
+		if info.Mapping != nil {
+			gvk = info.Mapping.GroupVersionKind
+		}
+		rule, found := readinessRules[gvk.GroupKind()]
+		if !found {
+			continue
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(info.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s %s to determine readiness: %w", gvk.Kind, info.Name, err)
+		}
+		ready, message, err := rule(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine readiness of %s %s: %w", gvk.Kind, info.Name, err)
+		}
+		readiness = append(readiness, shared.ResourceReadiness{
+			Resource: shared.Resource{
+				Name:             info.Name,
+				Namespace:        info.Namespace,
+				GroupVersionKind: metav1.GroupVersionKind(gvk),
+			},
+			Ready:   ready,
+			Message: message,
+		})
+	}
+	return readiness, nil
+}
+
+// FirstUnready returns the first resource that is not ready, if any.
+func FirstUnready(readiness []shared.ResourceReadiness) (shared.ResourceReadiness, bool) {
+	for _, res := range readiness {
+		if !res.Ready {
+			return res, true
+		}
+	}
+	return shared.ResourceReadiness{}, false
+}
+
+func deploymentReadiness(obj map[string]any) (bool, string, error) {
+	deploy := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, deploy); err != nil {
+		return false, "", fmt.Errorf("invalid Deployment: %w", err)
+	}
+	if deploy.Status.ObservedGeneration < deploy.Generation {
+		return false, "update is not observed yet", nil
+	}
+	desired := replicasOrDefault(deploy.Spec.Replicas)
+	if deploy.Status.UpdatedReplicas < desired {
+		return false, fmt.Sprintf("%d of %d replicas are updated", deploy.Status.UpdatedReplicas, desired), nil
+	}
+	if deploy.Status.AvailableReplicas < desired {
+		return false, fmt.Sprintf("%d of %d replicas are available", deploy.Status.AvailableReplicas, desired), nil
+	}
+	return true, "", nil
+}
+
+func statefulSetReadiness(obj map[string]any) (bool, string, error) {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, statefulSet); err != nil {
+		return false, "", fmt.Errorf("invalid StatefulSet: %w", err)
+	}
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return false, "update is not observed yet", nil
+	}
+	desired := replicasOrDefault(statefulSet.Spec.Replicas)
+	if statefulSet.Status.UpdatedReplicas < desired {
+		return false, fmt.Sprintf("%d of %d replicas are updated", statefulSet.Status.UpdatedReplicas, desired), nil
+	}
+	if statefulSet.Status.ReadyReplicas < desired {
+		return false, fmt.Sprintf("%d of %d replicas are ready", statefulSet.Status.ReadyReplicas, desired), nil
+	}
+	return true, "", nil
+}
+
+func daemonSetReadiness(obj map[string]any) (bool, string, error) {
+	daemonSet := &appsv1.DaemonSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, daemonSet); err != nil {
+		return false, "", fmt.Errorf("invalid DaemonSet: %w", err)
+	}
+	if daemonSet.Status.ObservedGeneration < daemonSet.Generation {
+		return false, "update is not observed yet", nil
+	}
+	desired := daemonSet.Status.DesiredNumberScheduled
+	if daemonSet.Status.UpdatedNumberScheduled < desired {
+		return false, fmt.Sprintf("%d of %d pods are updated", daemonSet.Status.UpdatedNumberScheduled, desired), nil
+	}
+	if daemonSet.Status.NumberAvailable < desired {
+		return false, fmt.Sprintf("%d of %d pods are available", daemonSet.Status.NumberAvailable, desired), nil
+	}
+	return true, "", nil
+}
+
+func jobReadiness(obj map[string]any) (bool, string, error) {
+	job := &batchv1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, job); err != nil {
+		return false, "", fmt.Errorf("invalid Job: %w", err)
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, "", nil
+		case batchv1.JobFailed:
+			return false, fmt.Sprintf("job failed: %s", condition.Message), nil
+		}
+	}
+	return false, "job is not complete", nil
+}
+
+func persistentVolumeClaimReadiness(obj map[string]any) (bool, string, error) {
+	phase, _, err := unstructured.NestedString(obj, "status", "phase")
+	if err != nil {
+		return false, "", fmt.Errorf("invalid PersistentVolumeClaim: %w", err)
+	}
+	if phase != string(corev1.ClaimBound) {
+		return false, fmt.Sprintf("claim is not bound (phase %q)", phase), nil
+	}
+	return true, "", nil
+}
+
+// conditionReadiness is ready once the condition of the given type is true.
+func conditionReadiness(conditionType string) readinessRule {
+	return func(obj map[string]any) (bool, string, error) {
+		conditions, _, err := unstructured.NestedSlice(obj, "status", "conditions")
+		if err != nil {
+			return false, "", fmt.Errorf("invalid conditions: %w", err)
+		}
+		for _, condition := range conditions {
+			condition, ok := condition.(map[string]any)
+			if !ok || condition["type"] != conditionType {
+				continue
+			}
+			if condition["status"] == string(metav1.ConditionTrue) {
+				return true, "", nil
+			}
+			if message, ok := condition["message"].(string); ok && message != "" {
+				return false, fmt.Sprintf("condition %s is not true: %s", conditionType, message), nil
+			}
+		}
+		return false, fmt.Sprintf("condition %s is not true", conditionType), nil
+	}
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
@@ -0,0 +1,141 @@
+package manifest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+
+	"github.com/kyma-project/lifecycle-manager/internal/manifest"
+)
+
+func readinessInfo(apiVersion, kind string, spec, status map[string]any) *resource.Info {
+	obj := &unstructured.Unstructured{Object: map[string]any{}}
+	if spec != nil {
+		obj.Object["spec"] = spec
+	}
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName("test")
+	obj.SetNamespace("kyma-system")
+	obj.SetGeneration(2)
+	return &resource.Info{Name: obj.GetName(), Namespace: obj.GetNamespace(), Object: obj}
+}
+
+func conditions(conditionType, status string) map[string]any {
+	return map[string]any{"conditions": []any{
+		map[string]any{"type": conditionType, "status": status, "message": "reason"},
+	}}
+}
+
+//nolint:funlen
+func TestEvaluateReadiness(t *testing.T) {
+	t.Parallel()
+
+	replicas := map[string]any{"replicas": int64(2)}
+	tests := []struct {
+		name        string
+		info        *resource.Info
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			"available deployment",
+			readinessInfo("apps/v1", "Deployment", replicas, map[string]any{
+				"observedGeneration": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2),
+			}),
+			true, "",
+		},
+		{
+			"deployment with unobserved update",
+			readinessInfo("apps/v1", "Deployment", replicas, map[string]any{
+				"observedGeneration": int64(1), "updatedReplicas": int64(2), "availableReplicas": int64(2),
+			}),
+			false, "update is not observed yet",
+		},
+		{
+			"deployment rolling out",
+			readinessInfo("apps/v1", "Deployment", replicas, map[string]any{
+				"observedGeneration": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(1),
+			}),
+			false, "1 of 2 replicas are available",
+		},
+		{
+			"statefulset not ready",
+			readinessInfo("apps/v1", "StatefulSet", replicas, map[string]any{
+				"observedGeneration": int64(2), "updatedReplicas": int64(2), "readyReplicas": int64(0),
+			}),
+			false, "0 of 2 replicas are ready",
+		},
+		{
+			"daemonset updating",
+			readinessInfo("apps/v1", "DaemonSet", nil, map[string]any{
+				"observedGeneration": int64(2), "desiredNumberScheduled": int64(3),
+				"updatedNumberScheduled": int64(1), "numberAvailable": int64(3),
+			}),
+			false, "1 of 3 pods are updated",
+		},
+		{
+			"completed job",
+			readinessInfo("batch/v1", "Job", nil, conditions("Complete", "True")),
+			true, "",
+		},
+		{
+			"failed job",
+			readinessInfo("batch/v1", "Job", nil, conditions("Failed", "True")),
+			false, "job failed: reason",
+		},
+		{
+			"pending claim",
+			readinessInfo("v1", "PersistentVolumeClaim", nil, map[string]any{"phase": "Pending"}),
+			false, "claim is not bound (phase \"Pending\")",
+		},
+		{
+			"established crd",
+			readinessInfo("apiextensions.k8s.io/v1", "CustomResourceDefinition", nil,
+				conditions("Established", "True")),
+			true, "",
+		},
+		{
+			"unavailable apiservice",
+			readinessInfo("apiregistration.k8s.io/v1", "APIService", nil, conditions("Available", "False")),
+			false, "condition Available is not true: reason",
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			readiness, err := manifest.EvaluateReadiness([]*resource.Info{testCase.info})
+			require.NoError(t, err)
+			require.Len(t, readiness, 1)
+			assert.Equal(t, testCase.wantReady, readiness[0].Ready)
+			assert.Equal(t, testCase.wantMessage, readiness[0].Message)
+		})
+	}
+}
+
+func TestFirstUnready(t *testing.T) {
+	t.Parallel()
+
+	readiness, err := manifest.EvaluateReadiness([]*resource.Info{
+		readinessInfo("v1", "ConfigMap", nil, nil),
+		readinessInfo("batch/v1", "Job", nil, conditions("Complete", "True")),
+		readinessInfo("v1", "PersistentVolumeClaim", nil, map[string]any{"phase": "Pending"}),
+		readinessInfo("batch/v1", "Job", nil, nil),
+	})
+	require.NoError(t, err)
+	require.Len(t, readiness, 3, "only resources with a readiness rule are evaluated")
+
+	unready, found := manifest.FirstUnready(readiness)
+	require.True(t, found)
+	assert.Equal(t, "PersistentVolumeClaim", unready.Kind)
+
+	_, found = manifest.FirstUnready(readiness[:1])
+	assert.False(t, found)
+}
@@ -0,0 +1,111 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+// stateCheckExpressionCostLimit bounds the evaluation cost of a CustomStateCheck Expression.
+const stateCheckExpressionCostLimit = 1_000_000
+
+var ErrInvalidStateCheckExpression = errors.New("invalid custom state check expression")
+
+// CompileStateCheckExpression compiles the CEL expression of a CustomStateCheck into a program
+// that evaluates to a bool, with the Module CR available as self.
+func CompileStateCheckExpression(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable(v1beta2.StateCheckExpressionVariable, cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStateCheckExpression, issues.Err())
+	}
+	if !ast.OutputType().IsAssignableType(cel.BoolType) {
+		return nil, fmt.Errorf("%w: expression evaluates to %s instead of bool",
+			ErrInvalidStateCheckExpression, ast.OutputType())
+	}
+	program, err := env.Program(ast, cel.CostLimit(stateCheckExpressionCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStateCheckExpression, err)
+	}
+	return program, nil
+}
+
+// ModuleTemplateValidator validates a ModuleTemplate like its webhook.Validator, and additionally compiles
+// the Expressions of its CustomStateChecks, which the API package does not do to avoid depending on CEL.
+type ModuleTemplateValidator struct{}
+
+var _ webhook.CustomValidator = ModuleTemplateValidator{}
+
+func (v ModuleTemplateValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1beta2.ModuleTemplate{}).
+		WithValidator(v).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed to setup webhook with manager for ModuleTemplate: %w", err)
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v ModuleTemplateValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	template, ok := obj.(*v1beta2.ModuleTemplate)
+	if !ok {
+		return nil, v1beta2.ErrTypeAssertModuleTemplate
+	}
+	if warnings, err := template.ValidateCreate(); err != nil {
+		return warnings, err //nolint:wrapcheck
+	}
+	return nil, validateStateCheckExpressions(template)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v ModuleTemplateValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	template, ok := newObj.(*v1beta2.ModuleTemplate)
+	if !ok {
+		return nil, v1beta2.ErrTypeAssertModuleTemplate
+	}
+	if warnings, err := template.ValidateUpdate(oldObj); err != nil {
+		return warnings, err //nolint:wrapcheck
+	}
+	return nil, validateStateCheckExpressions(template)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v ModuleTemplateValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateStateCheckExpressions(template *v1beta2.ModuleTemplate) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec").Child("customStateCheck")
+	for i, stateCheck := range template.Spec.CustomStateCheck {
+		if stateCheck.Expression == "" {
+			continue
+		}
+		if _, err := CompileStateCheckExpression(stateCheck.Expression); err != nil {
+			errs = append(errs, field.Invalid(path.Index(i).Child("expression"), stateCheck.Expression, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: v1beta2.GroupVersion.Group, Kind: string(v1beta2.ModuleTemplateKind)},
+			template.GetName(), errs)
+	}
+	return nil
+}
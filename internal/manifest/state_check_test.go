@@ -0,0 +1,63 @@
+package manifest_test
+
+import (
+	"context"
+	"testing"
+
+	compdescv2 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/versions/v2"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/internal/manifest"
+	"github.com/kyma-project/lifecycle-manager/pkg/testutils/builder"
+)
+
+func TestCompileStateCheckExpression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{
+			name:       "valid expression",
+			expression: `self.status.conditions.exists(c, c.type == "Installed" && c.status == "True")`,
+		},
+		{name: "invalid syntax", expression: "self.status.health ==", wantErr: true},
+		{name: "not evaluating to bool", expression: `"green"`, wantErr: true},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := manifest.CompileStateCheckExpression(testCase.expression)
+
+			if testCase.wantErr {
+				require.ErrorIs(t, err, manifest.ErrInvalidStateCheckExpression)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestModuleTemplateValidator_ValidateCreate(t *testing.T) {
+	t.Parallel()
+
+	template := builder.NewModuleTemplateBuilder().WithOCM(compdescv2.SchemaVersion).Build()
+	template.Spec.CustomStateCheck = []*v1beta2.CustomStateCheck{
+		{JSONPath: "status.health", Value: "green", MappedState: "Ready"},
+		{Expression: "self.status.health ==", MappedState: "Error"},
+	}
+
+	_, err := manifest.ModuleTemplateValidator{}.ValidateCreate(context.Background(), template)
+	require.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+	require.Contains(t, err.Error(), "spec.customStateCheck[1].expression")
+
+	template.Spec.CustomStateCheck[1].Expression = `self.status.health == "red"`
+	_, err = manifest.ModuleTemplateValidator{}.ValidateCreate(context.Background(), template)
+	require.NoError(t, err)
+}
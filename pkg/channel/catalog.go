@@ -0,0 +1,213 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+// catalogVersionLimit bounds the versions listed per channel of a module, so that the status of a Kyma stays
+// small even if a module offers many versions, e.g. with a ModuleTemplate per version. The latest are listed.
+const catalogVersionLimit = 5
+
+// ModuleCatalog computes the catalogs of Kymas. The offer of a ModuleTemplate, which requires its descriptor to be
+// decoded, is only computed again once the ModuleTemplate changed, and only the offers of the ModuleTemplates listed
+// last are kept. The zero value is ready to use and safe for concurrent use.
+type ModuleCatalog struct {
+	mu     sync.Mutex
+	offers map[string]*catalogOffer
+}
+
+// catalogOffer is the version of a module offered by a ModuleTemplate, together with what decides its visibility.
+type catalogOffer struct {
+	revision string
+	module   v1beta2.CatalogModule
+	version  v1beta2.CatalogVersion
+	template *v1beta2.ModuleTemplate
+}
+
+// For lists the modules offered to the Kyma by the ModuleTemplates in the cluster of the reader, which should be
+// backed by a cache, and in the Sources of the options. If a source is unavailable, the modules of the others
+// are listed nonetheless, together with an error wrapping ErrModuleSourceUnavailable.
+func (c *ModuleCatalog) For(ctx context.Context, reader client.Reader, opts LookupOptions, kyma *v1beta2.Kyma) (
+	[]v1beta2.CatalogModule, error,
+) {
+	templates, err := listFromSources(ctx, uncopiedClusterSource{reader: reader}, opts.Sources, "", "")
+	if err != nil && !errors.Is(err, ErrModuleSourceUnavailable) {
+		return nil, err
+	}
+	return newCatalog(kyma, c.offersOf(templates)), err
+}
+
+func (c *ModuleCatalog) offersOf(templates []v1beta2.ModuleTemplate) []*catalogOffer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	offers := make(map[string]*catalogOffer, len(templates))
+	listed := make([]*catalogOffer, 0, len(templates))
+	for i := range templates {
+		template := &templates[i]
+		key := client.ObjectKeyFromObject(template).String()
+		revision := revisionOf(template)
+		offer, found := c.offers[key]
+		if !found || offer == nil || offer.revision != revision {
+			offer = newCatalogOffer(template, revision)
+		}
+		offers[key] = offer
+		if offer != nil {
+			listed = append(listed, offer)
+		}
+	}
+	c.offers = offers
+	return listed
+}
+
+// revisionOf identifies the state of a ModuleTemplate. The ModuleTemplates of sources have no resource version,
+// so the fields the offer is computed from are hashed instead.
+func revisionOf(template *v1beta2.ModuleTemplate) string {
+	if template.ResourceVersion != "" {
+		return template.ResourceVersion
+	}
+	hash := fnv.New64a()
+	fmt.Fprint(hash, template.Labels, template.Spec.Channel, template.Spec.Audience.String())
+	hash.Write(template.Spec.Descriptor.Raw)
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+// newCatalogOffer returns nil for ModuleTemplates that are deleted or have no valid descriptor,
+// as no module can be installed from them.
+func newCatalogOffer(template *v1beta2.ModuleTemplate, revision string) *catalogOffer {
+	if !template.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	descriptor, err := template.GetDescriptor()
+	if err != nil {
+		return nil
+	}
+	moduleName := template.GetLabels()[v1beta2.ModuleName]
+	if moduleName == "" {
+		moduleName = descriptor.Name
+	}
+	return &catalogOffer{
+		revision: revision,
+		module: v1beta2.CatalogModule{
+			Name:     moduleName,
+			FQDN:     descriptor.Name,
+			Provider: string(descriptor.Provider.Name),
+		},
+		version: v1beta2.CatalogVersion{
+			Channel:  template.Spec.Channel,
+			Version:  descriptor.Version,
+			Beta:     template.IsBeta(),
+			Internal: template.IsInternal(),
+		},
+		template: &v1beta2.ModuleTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: template.Name, Labels: template.Labels},
+			Spec:       v1beta2.ModuleTemplateSpec{Audience: template.Spec.Audience},
+		},
+	}
+}
+
+// NewCatalog lists the modules offered to the Kyma by the given ModuleTemplates, skipping the ones that are not
+// visible to it, as determined by DetermineTemplatesVisibility. ModuleTemplates without a valid descriptor are not
+// offered, as no module can be installed from them. At most catalogVersionLimit versions are listed per channel.
+func NewCatalog(kyma *v1beta2.Kyma, templates []v1beta2.ModuleTemplate) []v1beta2.CatalogModule {
+	offers := make([]*catalogOffer, 0, len(templates))
+	for i := range templates {
+		if offer := newCatalogOffer(&templates[i], ""); offer != nil {
+			offers = append(offers, offer)
+		}
+	}
+	return newCatalog(kyma, offers)
+}
+
+func newCatalog(kyma *v1beta2.Kyma, offers []*catalogOffer) []v1beta2.CatalogModule {
+	modules := make(map[string]*v1beta2.CatalogModule)
+	for _, offer := range offers {
+		if !isVisibleTo(kyma, offer.template) {
+			continue
+		}
+		module, found := modules[offer.module.Name]
+		if !found {
+			module = &v1beta2.CatalogModule{
+				Name:     offer.module.Name,
+				FQDN:     offer.module.FQDN,
+				Provider: offer.module.Provider,
+			}
+			modules[offer.module.Name] = module
+		}
+		module.Versions = append(module.Versions, offer.version)
+	}
+
+	catalog := make([]v1beta2.CatalogModule, 0, len(modules))
+	for _, module := range modules {
+		sort.Slice(module.Versions, func(i, j int) bool {
+			if module.Versions[i].Channel != module.Versions[j].Channel {
+				return module.Versions[i].Channel < module.Versions[j].Channel
+			}
+			return versionLess(module.Versions[i].Version, module.Versions[j].Version)
+		})
+		module.Versions = latestVersionsPerChannel(module.Versions)
+		catalog = append(catalog, *module)
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].Name < catalog[j].Name
+	})
+	return catalog
+}
+
+// latestVersionsPerChannel keeps the catalogVersionLimit latest of the versions ordered by channel and version.
+func latestVersionsPerChannel(versions []v1beta2.CatalogVersion) []v1beta2.CatalogVersion {
+	limited := make([]v1beta2.CatalogVersion, 0, len(versions))
+	for i, version := range versions {
+		later := 0
+		for j := i + 1; j < len(versions) && versions[j].Channel == version.Channel; j++ {
+			later++
+		}
+		if later < catalogVersionLimit {
+			limited = append(limited, version)
+		}
+	}
+	return limited
+}
+
+// uncopiedClusterSource lists all ModuleTemplates in the cluster without copying them from the cache of the reader.
+// The listed ModuleTemplates are shallow copies, so they must not be modified, but can decode their descriptor.
+type uncopiedClusterSource struct {
+	reader client.Reader
+}
+
+func (s uncopiedClusterSource) ListTemplates(ctx context.Context, _, _ string) ([]v1beta2.ModuleTemplate, error) {
+	templateList := &v1beta2.ModuleTemplateList{}
+	if err := s.reader.List(ctx, templateList, client.UnsafeDisableDeepCopy); err != nil {
+		return nil, fmt.Errorf("failed to list module templates for catalog: %w", err)
+	}
+	return templateList.Items, nil
+}
+
+func isVisibleTo(kyma *v1beta2.Kyma, template *v1beta2.ModuleTemplate) bool {
+	if (template.IsInternal() && !kyma.IsInternal()) || (template.IsBeta() && !kyma.IsBeta()) {
+		return false
+	}
+	matches, err := template.MatchesAudience(kyma.GetLabels())
+	return err == nil && matches
+}
+
+// versionLess orders semantic versions by precedence and falls back to a lexical order for other versions.
+func versionLess(left, right string) bool {
+	leftVersion, leftErr := semver.NewVersion(left)
+	rightVersion, rightErr := semver.NewVersion(right)
+	if leftErr != nil || rightErr != nil {
+		return left < right
+	}
+	return leftVersion.LessThan(rightVersion)
+}
@@ -0,0 +1,134 @@
+package channel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/testutils"
+)
+
+func TestNewCatalog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		kymaLabels   map[string]string
+		wantVersions []v1beta2.CatalogVersion
+	}{
+		{
+			"only public modules", nil,
+			[]v1beta2.CatalogVersion{
+				{Channel: "fast", Version: "v1.11.0"},
+				{Channel: "regular", Version: "v1.9.1"},
+				{Channel: "regular", Version: "v1.10.0"},
+			},
+		},
+		{
+			"beta modules", map[string]string{v1beta2.BetaLabel: v1beta2.EnableLabelValue},
+			[]v1beta2.CatalogVersion{
+				{Channel: "experimental", Version: "v2.0.0", Beta: true},
+				{Channel: "fast", Version: "v1.11.0"},
+				{Channel: "regular", Version: "v1.9.1"},
+				{Channel: "regular", Version: "v1.10.0"},
+			},
+		},
+		{
+			"internal modules", map[string]string{v1beta2.InternalLabel: v1beta2.EnableLabelValue},
+			[]v1beta2.CatalogVersion{
+				{Channel: "experimental", Version: "v2.1.0", Internal: true},
+				{Channel: "fast", Version: "v1.11.0"},
+				{Channel: "regular", Version: "v1.9.1"},
+				{Channel: "regular", Version: "v1.10.0"},
+			},
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			beta := newModuleTemplate("experimental", "v2.0.0")
+			beta.Labels[v1beta2.BetaLabel] = v1beta2.EnableLabelValue
+			internal := newModuleTemplate("experimental", "v2.1.0")
+			internal.Labels[v1beta2.InternalLabel] = v1beta2.EnableLabelValue
+			templates := []v1beta2.ModuleTemplate{
+				*newModuleTemplate("regular", "v1.10.0"), *newModuleTemplate("fast", "v1.11.0"),
+				*newModuleTemplate("regular", "v1.9.1"), *beta, *internal,
+			}
+			kyma := testutils.NewTestKyma("test-kyma")
+			for key, value := range testCase.kymaLabels {
+				kyma.Labels[key] = value
+			}
+
+			catalog := channel.NewCatalog(kyma, templates)
+
+			require.Len(t, catalog, 1)
+			assert.Equal(t, testModuleName, catalog[0].Name)
+			assert.NotEmpty(t, catalog[0].FQDN)
+			assert.Equal(t, testCase.wantVersions, catalog[0].Versions)
+		})
+	}
+}
+
+func TestNewCatalog_LimitsVersionsPerChannel(t *testing.T) {
+	t.Parallel()
+
+	var templates []v1beta2.ModuleTemplate
+	for minor := 6; minor >= 0; minor-- {
+		template := newModuleTemplate("regular", fmt.Sprintf("v1.%d.0", minor))
+		template.Name = fmt.Sprintf("%s-regular-%d", testModuleName, minor)
+		templates = append(templates, *template)
+	}
+	templates = append(templates, *newModuleTemplate("fast", "v2.0.0"))
+
+	catalog := channel.NewCatalog(testutils.NewTestKyma("test-kyma"), templates)
+
+	require.Len(t, catalog, 1)
+	assert.Equal(t, []v1beta2.CatalogVersion{
+		{Channel: "fast", Version: "v2.0.0"},
+		{Channel: "regular", Version: "v1.2.0"},
+		{Channel: "regular", Version: "v1.3.0"},
+		{Channel: "regular", Version: "v1.4.0"},
+		{Channel: "regular", Version: "v1.5.0"},
+		{Channel: "regular", Version: "v1.6.0"},
+	}, catalog[0].Versions)
+}
+
+func TestModuleCatalog_For(t *testing.T) {
+	t.Parallel()
+
+	template := newModuleTemplate("regular", "v1.0.0")
+	clnt := newFakeClient(template)
+	kyma := testutils.NewTestKyma("test-kyma")
+	moduleCatalog := &channel.ModuleCatalog{}
+	ctx := context.Background()
+	versionsFor := func() []v1beta2.CatalogVersion {
+		t.Helper()
+		catalog, err := moduleCatalog.For(ctx, clnt, channel.LookupOptions{}, kyma)
+		require.NoError(t, err)
+		if len(catalog) == 0 {
+			return nil
+		}
+		require.Len(t, catalog, 1)
+		return catalog[0].Versions
+	}
+
+	assert.Equal(t, []v1beta2.CatalogVersion{{Channel: "regular", Version: "v1.0.0"}}, versionsFor())
+	assert.Equal(t, []v1beta2.CatalogVersion{{Channel: "regular", Version: "v1.0.0"}}, versionsFor(),
+		"an unchanged template is offered from the kept offer")
+
+	require.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(template), template))
+	template.Spec.Descriptor = newModuleTemplate("regular", "v1.1.0").Spec.Descriptor
+	require.NoError(t, clnt.Update(ctx, template))
+	assert.Equal(t, []v1beta2.CatalogVersion{{Channel: "regular", Version: "v1.1.0"}}, versionsFor(),
+		"a changed template is offered again")
+
+	require.NoError(t, clnt.Delete(ctx, template))
+	assert.Empty(t, versionsFor())
+}
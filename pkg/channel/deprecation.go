@@ -0,0 +1,99 @@
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+var (
+	ErrInvalidChannelDeprecation = errors.New("invalid channel deprecation")
+	ErrChannelDeprecated         = errors.New("channel deprecated")
+)
+
+const sunsetDateLayout = time.DateOnly
+
+// Deprecation marks a channel as deprecated. After the Sunset, modules still using the channel are migrated
+// to the Successor channel, if one is configured.
+type Deprecation struct {
+	Channel   string
+	Sunset    time.Time
+	Successor string
+}
+
+// IsSunset returns true if the channel reached its end of life at the given time.
+func (d *Deprecation) IsSunset(now time.Time) bool {
+	return !now.Before(d.Sunset)
+}
+
+func (d *Deprecation) String() string {
+	msg := fmt.Sprintf("channel %s is deprecated and reaches its end of life at %s",
+		d.Channel, d.Sunset.Format(time.RFC3339))
+	if d.Successor != "" {
+		msg += ", modules are migrated to channel " + d.Successor
+	}
+	return msg
+}
+
+// Deprecations holds the cluster-wide Deprecation of each deprecated channel.
+type Deprecations map[string]Deprecation
+
+// ParseDeprecations creates Deprecations from a comma-separated list of channels with their sunset date and
+// optional successor like "alpha=2024-06-30->regular". The sunset is either a date or an RFC3339 timestamp.
+func ParseDeprecations(deprecationList string) (Deprecations, error) {
+	deprecations := make(Deprecations)
+	for _, entry := range splitList(deprecationList) {
+		channel, sunsetAndSuccessor, found := strings.Cut(entry, aliasSeparator)
+		channel = strings.TrimSpace(channel)
+		if !found || channel == "" {
+			return nil, fmt.Errorf("%w: %q is not of the form channel=sunset->successor",
+				ErrInvalidChannelDeprecation, entry)
+		}
+		sunsetValue, successor, _ := strings.Cut(sunsetAndSuccessor, fallbackSeparator)
+		deprecation, err := newDeprecation(channel, sunsetValue, successor)
+		if err != nil {
+			return nil, err
+		}
+		if _, defined := deprecations[channel]; defined {
+			return nil, fmt.Errorf("%w: channel %s is deprecated more than once", ErrInvalidChannelDeprecation, channel)
+		}
+		deprecations[channel] = *deprecation
+	}
+	return deprecations, nil
+}
+
+// Of returns the Deprecation of the channel of the ModuleTemplate, or nil if the channel is not deprecated.
+// The v1beta2.ChannelSunsetAnnotation and v1beta2.ChannelSuccessorAnnotation of the ModuleTemplate take
+// precedence over the cluster-wide Deprecations.
+func (d Deprecations) Of(template *v1beta2.ModuleTemplate) (*Deprecation, error) {
+	channel := template.Spec.Channel
+	if sunset, found := template.GetAnnotations()[v1beta2.ChannelSunsetAnnotation]; found {
+		deprecation, err := newDeprecation(channel, sunset, template.GetAnnotations()[v1beta2.ChannelSuccessorAnnotation])
+		if err != nil {
+			return nil, fmt.Errorf("ModuleTemplate %s/%s: %w", template.GetNamespace(), template.GetName(), err)
+		}
+		return deprecation, nil
+	}
+	if deprecation, found := d[channel]; found {
+		return &deprecation, nil
+	}
+	return nil, nil //nolint:nilnil // a channel without deprecation is not an error
+}
+
+func newDeprecation(channel, sunsetValue, successor string) (*Deprecation, error) {
+	sunsetValue, successor = strings.TrimSpace(sunsetValue), strings.TrimSpace(successor)
+	sunset, err := time.Parse(sunsetDateLayout, sunsetValue)
+	if err != nil {
+		if sunset, err = time.Parse(time.RFC3339, sunsetValue); err != nil {
+			return nil, fmt.Errorf("%w: sunset %q of channel %s is neither a date nor an RFC3339 timestamp",
+				ErrInvalidChannelDeprecation, sunsetValue, channel)
+		}
+	}
+	if successor == channel {
+		return nil, fmt.Errorf("%w: channel %s cannot succeed itself", ErrInvalidChannelDeprecation, channel)
+	}
+	return &Deprecation{Channel: channel, Sunset: sunset, Successor: successor}, nil
+}
@@ -0,0 +1,146 @@
+package channel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+)
+
+func TestParseDeprecations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		deprecations  string
+		wantSunset    time.Time
+		wantSuccessor string
+		wantErr       bool
+	}{
+		{
+			"date with successor", "alpha=2024-06-30->regular",
+			time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), "regular", false,
+		},
+		{
+			"timestamp without successor", "alpha=2024-06-30T12:00:00Z",
+			time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC), "", false,
+		},
+		{"missing sunset", "alpha", time.Time{}, "", true},
+		{"invalid sunset", "alpha=tomorrow->regular", time.Time{}, "", true},
+		{"successor is the channel", "alpha=2024-06-30->alpha", time.Time{}, "", true},
+		{"deprecated twice", "alpha=2024-06-30,alpha=2024-07-30", time.Time{}, "", true},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			deprecations, err := channel.ParseDeprecations(testCase.deprecations)
+			if testCase.wantErr {
+				require.ErrorIs(t, err, channel.ErrInvalidChannelDeprecation)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, deprecations, "alpha")
+			assert.True(t, testCase.wantSunset.Equal(deprecations["alpha"].Sunset))
+			assert.Equal(t, testCase.wantSuccessor, deprecations["alpha"].Successor)
+		})
+	}
+}
+
+func TestTemplateLookup_DeprecatedChannel(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name             string
+		deprecations     channel.Deprecations
+		sunsetAnnotation string
+		wantChannel      string
+		wantDeprecated   bool
+	}{
+		{"not deprecated", nil, "", "alpha", false},
+		{
+			"before sunset",
+			channel.Deprecations{"alpha": {Channel: "alpha", Sunset: future, Successor: "regular"}},
+			"", "alpha", true,
+		},
+		{
+			"after sunset",
+			channel.Deprecations{"alpha": {Channel: "alpha", Sunset: past, Successor: "regular"}},
+			"", "regular", true,
+		},
+		{
+			"after sunset without successor",
+			channel.Deprecations{"alpha": {Channel: "alpha", Sunset: past}},
+			"", "alpha", true,
+		},
+		{
+			"after sunset with successor without template",
+			channel.Deprecations{"alpha": {Channel: "alpha", Sunset: past, Successor: "fast"}},
+			"", "alpha", true,
+		},
+		{
+			"template overrides sunset",
+			channel.Deprecations{"alpha": {Channel: "alpha", Sunset: past, Successor: "regular"}},
+			future.Format(time.RFC3339), "alpha", true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			alpha := newModuleTemplate("alpha", "v1.6.0")
+			if testCase.sunsetAnnotation != "" {
+				alpha.Annotations = map[string]string{
+					v1beta2.ChannelSunsetAnnotation:    testCase.sunsetAnnotation,
+					v1beta2.ChannelSuccessorAnnotation: "regular",
+				}
+			}
+			clnt := newFakeClient(alpha, newModuleTemplate("regular", "v1.6.1"))
+			module := v1beta2.Module{Name: testModuleName, Channel: "alpha"}
+
+			template := channel.NewTemplateLookup(clnt, module, v1beta2.DefaultChannel).
+				WithOptions(channel.LookupOptions{Deprecations: testCase.deprecations}).
+				WithContext(context.Background())
+
+			require.NoError(t, template.Err)
+			assert.Equal(t, testCase.wantChannel, template.Spec.Channel)
+			assert.Equal(t, testCase.wantDeprecated, template.Deprecation != nil)
+			assert.Equal(t, testCase.wantChannel != "alpha", template.IsMigrated())
+		})
+	}
+}
+
+func TestTemplateLookup_MigrationDoesNotDowngrade(t *testing.T) {
+	t.Parallel()
+
+	alpha := newModuleTemplate("alpha", "v1.6.0")
+	clnt := newFakeClient(alpha, newModuleTemplate("regular", "v1.5.0"))
+	deprecations := channel.Deprecations{
+		"alpha": {Channel: "alpha", Sunset: time.Now().Add(-time.Hour), Successor: "regular"},
+	}
+	module := v1beta2.Module{Name: testModuleName, Channel: "alpha"}
+
+	template := channel.NewTemplateLookup(clnt, module, v1beta2.DefaultChannel).
+		WithOptions(channel.LookupOptions{Deprecations: deprecations}).
+		WithContext(context.Background())
+	require.NoError(t, template.Err)
+	require.True(t, template.IsMigrated())
+
+	moduleStatus := &v1beta2.ModuleStatus{
+		Channel: "alpha",
+		Version: "v1.6.0",
+		Template: &v1beta2.TrackingObject{
+			PartialMeta: v1beta2.PartialMeta{Name: alpha.Name, Generation: alpha.Generation},
+		},
+	}
+	channel.CheckValidTemplateUpdate(logr.Discard(), &template, moduleStatus)
+	require.ErrorIs(t, template.Err, channel.ErrTemplateUpdateNotAllowed)
+}
@@ -0,0 +1,66 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+const (
+	// ModuleNameIndex indexes ModuleTemplates by their v1beta2.ModuleName label.
+	ModuleNameIndex = "moduleName"
+	// ChannelIndex indexes ModuleTemplates by their channel.
+	ChannelIndex = "spec.channel"
+	// DescriptorNameIndex indexes ModuleTemplates by the name of their descriptor, which is the FQDN of the module.
+	DescriptorNameIndex = "descriptorName"
+)
+
+// IndexModuleTemplates registers the field indexes queried by a TemplateLookup WithIndexes.
+func IndexModuleTemplates(ctx context.Context, indexer client.FieldIndexer) error {
+	indexes := []struct {
+		field   string
+		extract client.IndexerFunc
+	}{
+		{ModuleNameIndex, indexModuleName},
+		{ChannelIndex, indexChannel},
+		{DescriptorNameIndex, indexDescriptorName},
+	}
+	for _, index := range indexes {
+		if err := indexer.IndexField(ctx, &v1beta2.ModuleTemplate{}, index.field, index.extract); err != nil {
+			return fmt.Errorf("failed to index module templates by %s: %w", index.field, err)
+		}
+	}
+	return nil
+}
+
+func indexModuleName(obj client.Object) []string {
+	moduleName, found := obj.GetLabels()[v1beta2.ModuleName]
+	if !found {
+		return nil
+	}
+	return []string{moduleName}
+}
+
+func indexChannel(obj client.Object) []string {
+	template, ok := obj.(*v1beta2.ModuleTemplate)
+	if !ok || template.Spec.Channel == "" {
+		return nil
+	}
+	return []string{template.Spec.Channel}
+}
+
+func indexDescriptorName(obj client.Object) []string {
+	template, ok := obj.(*v1beta2.ModuleTemplate)
+	if !ok {
+		return nil
+	}
+	// the object is owned by the cache, so the descriptor is decoded from a copy.
+	descriptor, err := template.DeepCopy().GetDescriptor()
+	if err != nil {
+		return nil
+	}
+	return []string{descriptor.Name}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kyma-project/lifecycle-manager/pkg/remote"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
 	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlLog "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -23,19 +26,61 @@ var (
 	ErrInvalidRemoteModuleConfiguration = errors.New("invalid remote module template configuration")
 	ErrTemplateNotAllowed               = errors.New("module template not allowed")
 	ErrTemplateUpdateNotAllowed         = errors.New("module template update not allowed")
+	ErrTemplateUpdateDeferred           = errors.New("module template update deferred")
+	ErrTemplateDeleted                  = errors.New("module template deleted")
 	ErrModuleTemplateIsNil              = errors.New("module template is nil")
+	ErrInvalidVersionConstraint         = errors.New("invalid module version")
+	ErrInvalidAudience                  = errors.New("invalid module template audience")
 )
 
 type ModuleTemplateTO struct {
 	*v1beta2.ModuleTemplate
 	Err            error
 	DesiredChannel string
+	ResolvedBy     v1beta2.ModuleResolution
+	// RollbackFrom is the previously installed version if the template downgrades the module
+	// as permitted by v1beta2.RollbackVersionAnnotation.
+	RollbackFrom string
+	// Deprecation is set if the channel the module was looked up in is deprecated. If the channel reached
+	// its end of life, the ModuleTemplate is the one of the successor channel.
+	Deprecation *Deprecation
+	// Orphan is set if the ModuleTemplate of the installed module was deleted, see v1beta2.OrphanPolicy.
+	Orphan *Orphan
+}
+
+// Orphan describes an installed module whose ModuleTemplate was deleted.
+type Orphan struct {
+	Action v1beta2.OrphanAction
+	Since  metav1.Time
+	// UninstallAt is when the module is uninstalled by v1beta2.OrphanActionUninstall.
+	UninstallAt time.Time
+	// Uninstall is true once the module is due to be uninstalled.
+	Uninstall bool
+}
+
+// IsMigrated returns true if the module was migrated away from its deprecated channel.
+func (m *ModuleTemplateTO) IsMigrated() bool {
+	return m.Deprecation != nil && m.ModuleTemplate != nil && m.Spec.Channel != m.Deprecation.Channel
 }
 
 type ModuleTemplatesByModuleName map[string]*ModuleTemplateTO
 
+// LookupOptions configures the lookup of the ModuleTemplates of a Kyma.
+type LookupOptions struct {
+	// Resolver resolves channel aliases and fallbacks, a nil Resolver resolves every channel to itself only.
+	Resolver *Resolver
+	// Indexed queries the indexes registered with IndexModuleTemplates instead of listing all ModuleTemplates.
+	// GetTemplates only applies it to the ModuleTemplates in the control plane, as remote ones are not indexed.
+	Indexed bool
+	// Deprecations are the cluster-wide channel deprecations, which ModuleTemplates can override.
+	Deprecations Deprecations
+	// Sources offer ModuleTemplates besides the ones in the cluster. GetTemplates only applies them
+	// to the modules resolved in the control plane.
+	Sources []ModuleSource
+}
+
 func GetTemplates(
-	ctx context.Context, kymaClient client.Reader, kyma *v1beta2.Kyma, syncEnabled bool,
+	ctx context.Context, kymaClient client.Reader, kyma *v1beta2.Kyma, syncEnabled bool, opts LookupOptions,
 ) ModuleTemplatesByModuleName {
 	logger := ctrlLog.FromContext(ctx)
 	templates := make(ModuleTemplatesByModuleName)
@@ -45,7 +90,7 @@ func GetTemplates(
 
 		switch {
 		case module.RemoteModuleTemplateRef == "":
-			template = NewTemplateLookup(kymaClient, module, kyma.Spec.Channel).WithContext(ctx)
+			template = NewTemplateLookup(kymaClient, module, kyma.Spec.Channel).WithOptions(opts).WithContext(ctx)
 			if template.Err != nil {
 				break
 			}
@@ -63,7 +108,8 @@ func GetTemplates(
 			runtimeClient := syncContext.RuntimeClient
 			originalModuleName := module.Name
 			module.Name = module.RemoteModuleTemplateRef // To search template with the Remote Ref
-			template = NewTemplateLookup(runtimeClient, module, kyma.Spec.Channel).WithContext(ctx)
+			template = NewTemplateLookup(runtimeClient, module, kyma.Spec.Channel).
+				WithOptions(LookupOptions{Resolver: opts.Resolver}).WithContext(ctx)
 			module.Name = originalModuleName
 		default:
 			template.Err = fmt.Errorf("enable sync to use a remote module template for %s: %w", module.Name,
@@ -93,6 +139,17 @@ func DetermineTemplatesVisibility(kyma *v1beta2.Kyma, templates ModuleTemplatesB
 			moduleTemplate.Err = fmt.Errorf("%w: beta module", ErrTemplateNotAllowed)
 			templates[moduleName] = moduleTemplate
 		}
+		if moduleTemplate.Err != nil {
+			continue
+		}
+		// an invalid audience is an error of the ModuleTemplate rather than a decision to hide the module,
+		// so it must not uninstall the module from the Kyma.
+		if matches, err := moduleTemplate.MatchesAudience(kyma.GetLabels()); err != nil {
+			moduleTemplate.Err = fmt.Errorf("%w: %w", ErrInvalidAudience, err)
+		} else if !matches {
+			moduleTemplate.Err = fmt.Errorf("%w: audience %s does not select the labels of the Kyma",
+				ErrTemplateNotAllowed, metav1.FormatLabelSelector(moduleTemplate.Spec.Audience))
+		}
 	}
 }
 
@@ -175,6 +232,11 @@ func CheckValidTemplateUpdate(
 		// case we want to suspend updating the module until we reach v2.0.0 in regular, since downgrades
 		// are not supported. To circumvent this, a module can be uninstalled and then reinstalled in the old channel.
 		if !v1beta2.IsValidVersionChange(versionInTemplate, versionInStatus) {
+			if moduleTemplate.IsRollbackTo(descriptor.Version) {
+				checkLog.Info("channel skew rolls back the module as permitted by the template")
+				moduleTemplate.RollbackFrom = moduleStatus.Version
+				return
+			}
 			msg := fmt.Sprintf("ignore channel skew (from %s to %s), "+
 				"as a higher version (%s) of the module was previously installed",
 				moduleStatus.Channel, moduleTemplate.Spec.Channel, versionInStatus.String())
@@ -186,16 +248,59 @@ func CheckValidTemplateUpdate(
 		return
 	}
 
-	// generation skews always have to be handled. We are not in need of checking downgrades here,
-	// since these are caught by our validating webhook. We do not support downgrades of Versions
-	// in ModuleTemplates, meaning the only way the generation can be changed is by changing the target
-	// channel (valid change) or a version increase
+	// a version pin can resolve a different ModuleTemplate within the same channel, so the validating webhook
+	// does not protect us from a downgrade here and the versions have to be compared explicitly.
+	if moduleTemplate.ResolvedBy == v1beta2.ModuleResolvedByVersion &&
+		moduleStatus.Template.GetName() != moduleTemplate.GetName() {
+		checkLog.Info("outdated ModuleTemplate: version pin changed the template")
+		if err := checkVersionNotDecremented(moduleTemplate, moduleStatus); err != nil {
+			checkLog.Info(err.Error())
+			moduleTemplate.Err = fmt.Errorf("%w: %s", ErrTemplateUpdateNotAllowed, err.Error())
+		}
+		return
+	}
+
+	// generation skews always have to be handled. Downgrades of Versions in ModuleTemplates are rejected by
+	// our validating webhook unless the template permits a rollback, meaning the generation can only be changed
+	// by changing the target channel (valid change), a version increase or a permitted rollback.
 	if moduleTemplate.GetGeneration() != moduleStatus.Template.Generation {
 		checkLog.Info("outdated ModuleTemplate: generation skew")
+		if err := checkVersionNotDecremented(moduleTemplate, moduleStatus); errors.Is(err, errVersionDecremented) {
+			checkLog.Info(err.Error())
+			moduleTemplate.Err = fmt.Errorf("%w: %s", ErrTemplateUpdateNotAllowed, err.Error())
+		}
 		return
 	}
 }
 
+var errVersionDecremented = errors.New("version decrement")
+
+// checkVersionNotDecremented returns errVersionDecremented if the template downgrades the module
+// without permitting a rollback. A permitted rollback is recorded in ModuleTemplateTO.RollbackFrom.
+func checkVersionNotDecremented(moduleTemplate *ModuleTemplateTO, moduleStatus *v1beta2.ModuleStatus) error {
+	descriptor, err := moduleTemplate.GetDescriptor()
+	if err != nil {
+		return fmt.Errorf("could not compare versions as descriptor from template cannot be fetched: %w", err)
+	}
+	versionInTemplate, err := semver.NewVersion(descriptor.Version)
+	if err != nil {
+		return fmt.Errorf("could not compare versions as descriptor from template contains invalid version: %w", err)
+	}
+	versionInStatus, err := semver.NewVersion(moduleStatus.Version)
+	if err != nil {
+		return fmt.Errorf("could not compare versions as Modules contains invalid version: %w", err)
+	}
+	if v1beta2.IsValidVersionChange(versionInTemplate, versionInStatus) {
+		return nil
+	}
+	if moduleTemplate.IsRollbackTo(descriptor.Version) {
+		moduleTemplate.RollbackFrom = moduleStatus.Version
+		return nil
+	}
+	return fmt.Errorf("%w: ignore change to %s, as a higher version (%s) of the module "+
+		"was previously installed", errVersionDecremented, versionInTemplate, versionInStatus)
+}
+
 type Lookup interface {
 	WithContext(ctx context.Context) (*ModuleTemplateTO, error)
 }
@@ -214,12 +319,26 @@ type TemplateLookup struct {
 	reader         client.Reader
 	module         v1beta2.Module
 	defaultChannel string
+	opts           LookupOptions
+}
+
+// WithOptions resolves the desired channel with the Resolver of the options, queries the indexes
+// registered with IndexModuleTemplates if enabled and the Sources of the options. For indexes, the reader
+// of the lookup has to be backed by the cache the indexes are registered with.
+func (c *TemplateLookup) WithOptions(opts LookupOptions) *TemplateLookup {
+	c.opts = opts
+	return c
 }
 
 func (c *TemplateLookup) WithContext(ctx context.Context) ModuleTemplateTO {
 	desiredChannel := c.getDesiredChannel()
+	channels := c.opts.Resolver.Chain(desiredChannel)
+
+	if c.module.Version != "" {
+		return c.withVersion(ctx, desiredChannel, channels[0])
+	}
 
-	template, err := c.getTemplate(ctx, desiredChannel)
+	template, err := c.getTemplateFromChain(ctx, channels)
 	if err != nil {
 		return ModuleTemplateTO{
 			ModuleTemplate: nil,
@@ -242,6 +361,16 @@ func (c *TemplateLookup) WithContext(ctx context.Context) ModuleTemplateTO {
 		}
 	}
 
+	template, deprecation, err := c.migrateFromDeprecatedChannel(ctx, template)
+	if err != nil {
+		return ModuleTemplateTO{
+			ModuleTemplate: nil,
+			DesiredChannel: desiredChannel,
+			Err:            err,
+		}
+	}
+	actualChannel = template.Spec.Channel
+
 	logger := ctrlLog.FromContext(ctx)
 	if actualChannel != c.defaultChannel {
 		logger.V(log.DebugLevel).Info(
@@ -262,6 +391,58 @@ func (c *TemplateLookup) WithContext(ctx context.Context) ModuleTemplateTO {
 	return ModuleTemplateTO{
 		ModuleTemplate: template,
 		DesiredChannel: desiredChannel,
+		ResolvedBy:     v1beta2.ModuleResolvedByChannel,
+		Deprecation:    deprecation,
+		Err:            nil,
+	}
+}
+
+// migrateFromDeprecatedChannel returns the template of the successor channel if the channel of the template
+// reached its end of life. If the successor offers no template for the module, the template is kept.
+// Downgrades caused by the migration are prevented by the channel skew check of CheckValidTemplateUpdate.
+func (c *TemplateLookup) migrateFromDeprecatedChannel(ctx context.Context, template *v1beta2.ModuleTemplate) (
+	*v1beta2.ModuleTemplate, *Deprecation, error,
+) {
+	deprecation, err := c.opts.Deprecations.Of(template)
+	if err != nil || deprecation == nil {
+		return template, nil, err
+	}
+	if !deprecation.IsSunset(time.Now()) || deprecation.Successor == "" {
+		return template, deprecation, nil
+	}
+
+	successor, err := c.getTemplateFromChain(ctx, c.opts.Resolver.Chain(deprecation.Successor))
+	if err != nil {
+		ctrlLog.FromContext(ctx).Info(
+			fmt.Sprintf("cannot migrate module %s from deprecated channel %s", c.module.Name, deprecation.Channel),
+			"error", err.Error())
+		return template, deprecation, nil
+	}
+	return successor, deprecation, nil
+}
+
+func (c *TemplateLookup) withVersion(ctx context.Context, desiredChannel, preferredChannel string,
+) ModuleTemplateTO {
+	template, err := c.getTemplateByVersion(ctx, preferredChannel)
+	if err != nil {
+		return ModuleTemplateTO{
+			ModuleTemplate: nil,
+			DesiredChannel: desiredChannel,
+			Err:            err,
+		}
+	}
+
+	ctrlLog.FromContext(ctx).V(log.DebugLevel).Info(
+		fmt.Sprintf(
+			"using %s (pinned to %s) for module %s",
+			template.GetName(), c.module.Version, c.module.Name,
+		),
+	)
+
+	return ModuleTemplateTO{
+		ModuleTemplate: template,
+		DesiredChannel: desiredChannel,
+		ResolvedBy:     v1beta2.ModuleResolvedByVersion,
 		Err:            nil,
 	}
 }
@@ -281,49 +462,138 @@ func (c *TemplateLookup) getDesiredChannel() string {
 	return desiredChannel
 }
 
+// getTemplateFromChain returns the template of the first channel of the chain that offers one for the module.
+func (c *TemplateLookup) getTemplateFromChain(ctx context.Context, channels []string) (
+	*v1beta2.ModuleTemplate, error,
+) {
+	var err error
+	for _, channel := range channels {
+		var template *v1beta2.ModuleTemplate
+		template, err = c.getTemplate(ctx, channel)
+		if !errors.Is(err, ErrNoTemplatesInListResult) {
+			return template, err
+		}
+	}
+	if len(channels) == 1 {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: in channels %s for module %s",
+		ErrNoTemplatesInListResult, strings.Join(channels, fallbackSeparator), c.module.Name)
+}
+
 func (c *TemplateLookup) getTemplate(ctx context.Context, desiredChannel string) (
 	*v1beta2.ModuleTemplate, error,
 ) {
-	templateList := &v1beta2.ModuleTemplateList{}
-	err := c.reader.List(ctx, templateList)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list module templates on lookup: %w", err)
+	templates, sourceErr := c.listTemplates(ctx, desiredChannel)
+	if sourceErr != nil && !errors.Is(sourceErr, ErrModuleSourceUnavailable) {
+		return nil, sourceErr
 	}
 
 	moduleIdentifier := c.module.Name
 	var filteredTemplates []v1beta2.ModuleTemplate
-	for _, template := range templateList.Items {
-		if template.Labels[v1beta2.ModuleName] == moduleIdentifier && template.Spec.Channel == desiredChannel {
-			filteredTemplates = append(filteredTemplates, template)
+	for _, template := range templates {
+		if template.Spec.Channel != desiredChannel {
 			continue
 		}
-		if fmt.Sprintf("%s/%s", template.Namespace, template.Name) == moduleIdentifier &&
-			template.Spec.Channel == desiredChannel {
-			filteredTemplates = append(filteredTemplates, template)
-			continue
+		matches, err := templateMatchesModule(&template, moduleIdentifier)
+		if err != nil {
+			return nil, err
 		}
-		if template.ObjectMeta.Name == moduleIdentifier && template.Spec.Channel == desiredChannel {
+		if matches {
 			filteredTemplates = append(filteredTemplates, template)
+		}
+	}
+
+	if len(filteredTemplates) > 1 {
+		return nil, NewMoreThanOneTemplateCandidateErr(c.module, templates)
+	}
+	if len(filteredTemplates) == 0 && sourceErr != nil {
+		return nil, sourceErr
+	}
+	if len(filteredTemplates) == 0 {
+		return nil, fmt.Errorf("%w: in channel %s for module %s",
+			ErrNoTemplatesInListResult, desiredChannel, moduleIdentifier)
+	}
+	return &filteredTemplates[0], nil
+}
+
+// getTemplateByVersion resolves the ModuleTemplate with the highest descriptor version satisfying the
+// pinned Version of the module, regardless of its channel. If the same version is offered in several channels,
+// the template of the desired channel is preferred.
+func (c *TemplateLookup) getTemplateByVersion(ctx context.Context, desiredChannel string) (
+	*v1beta2.ModuleTemplate, error,
+) {
+	constraint, err := semver.NewConstraint(c.module.Version)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s for module %s: %w",
+			ErrInvalidVersionConstraint, c.module.Version, c.module.Name, err)
+	}
+
+	templates, sourceErr := c.listTemplates(ctx, "")
+	if sourceErr != nil && !errors.Is(sourceErr, ErrModuleSourceUnavailable) {
+		return nil, sourceErr
+	}
+
+	moduleIdentifier := c.module.Name
+	var bestTemplate *v1beta2.ModuleTemplate
+	var bestVersion *semver.Version
+	for i := range templates {
+		template := &templates[i]
+		matches, err := templateMatchesModule(template, moduleIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
 			continue
 		}
 		descriptor, err := template.GetDescriptor()
 		if err != nil {
 			return nil, fmt.Errorf("invalid ModuleTemplate descriptor: %w", err)
 		}
-		if descriptor.Name == moduleIdentifier && template.Spec.Channel == desiredChannel {
-			filteredTemplates = append(filteredTemplates, template)
+		version, err := semver.NewVersion(descriptor.Version)
+		if err != nil || !constraint.Check(version) {
 			continue
 		}
+		if bestVersion == nil || version.GreaterThan(bestVersion) ||
+			(version.Equal(bestVersion) && template.Spec.Channel == desiredChannel) {
+			bestTemplate, bestVersion = template, version
+		}
 	}
 
-	if len(filteredTemplates) > 1 {
-		return nil, NewMoreThanOneTemplateCandidateErr(c.module, templateList.Items)
+	if bestTemplate == nil && sourceErr != nil {
+		return nil, sourceErr
 	}
-	if len(filteredTemplates) == 0 {
-		return nil, fmt.Errorf("%w: in channel %s for module %s",
-			ErrNoTemplatesInListResult, desiredChannel, moduleIdentifier)
+	if bestTemplate == nil {
+		return nil, fmt.Errorf("%w: with version %s for module %s",
+			ErrNoTemplatesInListResult, c.module.Version, moduleIdentifier)
 	}
-	return &filteredTemplates[0], nil
+	return bestTemplate, nil
+}
+
+// listTemplates lists the candidates for the ModuleTemplate of the module in the cluster and in the Sources
+// of the options, optionally restricted to a channel. The candidates of the available sources are listed
+// even if another source is unavailable, the module is only considered missing if all sources are available.
+func (c *TemplateLookup) listTemplates(ctx context.Context, channel string) ([]v1beta2.ModuleTemplate, error) {
+	return listFromSources(ctx, NewClusterSource(c.reader, c.opts.Indexed), c.opts.Sources, c.module.Name, channel)
+}
+
+// templateMatchesModule checks if the given template can be identified by the module identifier,
+// which can be the module name label, the Name or Namespace/Name of the template or the descriptor FQDN.
+func templateMatchesModule(template *v1beta2.ModuleTemplate, moduleIdentifier string) (bool, error) {
+	if template.Labels[v1beta2.ModuleName] == moduleIdentifier {
+		return true, nil
+	}
+	if fmt.Sprintf("%s/%s", template.Namespace, template.Name) == moduleIdentifier {
+		return true, nil
+	}
+	if template.ObjectMeta.Name == moduleIdentifier {
+		return true, nil
+	}
+	descriptor, err := template.GetDescriptor()
+	if err != nil {
+		return false, fmt.Errorf("invalid ModuleTemplate descriptor: %w", err)
+	}
+	return descriptor.Name == moduleIdentifier, nil
 }
 
 func NewMoreThanOneTemplateCandidateErr(component v1beta2.Module,
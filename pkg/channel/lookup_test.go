@@ -0,0 +1,315 @@
+package channel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	compdescv2 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/versions/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/testutils/builder"
+)
+
+const testModuleName = "test-module"
+
+func newModuleTemplate(channelName, version string) *v1beta2.ModuleTemplate {
+	return newModuleTemplateBuilder(channelName, version).Build()
+}
+
+func newModuleTemplateBuilder(channelName, version string) builder.ModuleTemplateBuilder {
+	return builder.NewModuleTemplateBuilder().
+		WithModuleName(testModuleName).
+		WithChannel(channelName).
+		WithOCM(compdescv2.SchemaVersion).
+		WithDescriptorVersion(version)
+}
+
+func newFakeClient(templates ...*v1beta2.ModuleTemplate) client.Client {
+	return newFakeClientBuilder(templates...).Build()
+}
+
+// builderIndexer registers the indexes of channel.IndexModuleTemplates with a fake client.
+type builderIndexer struct {
+	builder *fake.ClientBuilder
+}
+
+func (i builderIndexer) IndexField(_ context.Context, obj client.Object, field string,
+	extractValue client.IndexerFunc,
+) error {
+	i.builder.WithIndex(obj, field, extractValue)
+	return nil
+}
+
+func newIndexedFakeClient(templates ...*v1beta2.ModuleTemplate) client.Client {
+	clientBuilder := newFakeClientBuilder(templates...)
+	if err := channel.IndexModuleTemplates(context.Background(), builderIndexer{clientBuilder}); err != nil {
+		panic(err)
+	}
+	return clientBuilder.Build()
+}
+
+func newFakeClientBuilder(templates ...*v1beta2.ModuleTemplate) *fake.ClientBuilder {
+	scheme := runtime.NewScheme()
+	if err := v1beta2.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	objs := make([]client.Object, 0, len(templates))
+	for _, template := range templates {
+		objs = append(objs, template)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
+}
+
+func TestTemplateLookup_WithVersion(t *testing.T) {
+	t.Parallel()
+
+	fast := newModuleTemplate("fast", "v1.5.0")
+	regular := newModuleTemplate("regular", "v1.4.2")
+	older := newModuleTemplate("legacy", "v1.4.1")
+	clnt := newFakeClient(fast, regular, older)
+
+	tests := []struct {
+		name         string
+		version      string
+		wantTemplate string
+		wantErr      error
+	}{
+		{"exact version", "1.4.1", older.Name, nil},
+		{"version range picks highest match", "~1.4", regular.Name, nil},
+		{"version range across channels", ">=1.4", fast.Name, nil},
+		{"no matching version", "2.x", "", channel.ErrNoTemplatesInListResult},
+		{"invalid version", "not-a-version", "", channel.ErrInvalidVersionConstraint},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			module := v1beta2.Module{Name: testModuleName, Version: testCase.version}
+			template := channel.NewTemplateLookup(clnt, module, v1beta2.DefaultChannel).
+				WithContext(context.Background())
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, template.Err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, template.Err)
+			assert.Equal(t, testCase.wantTemplate, template.GetName())
+			assert.Equal(t, v1beta2.ModuleResolvedByVersion, template.ResolvedBy)
+		})
+	}
+}
+
+func TestTemplateLookup_WithChannel(t *testing.T) {
+	t.Parallel()
+
+	regular := newModuleTemplate("regular", "v1.4.2")
+	clnt := newFakeClient(newModuleTemplate("fast", "v1.5.0"), regular)
+
+	module := v1beta2.Module{Name: testModuleName}
+	template := channel.NewTemplateLookup(clnt, module, v1beta2.DefaultChannel).WithContext(context.Background())
+
+	require.NoError(t, template.Err)
+	assert.Equal(t, regular.Name, template.GetName())
+	assert.Equal(t, v1beta2.ModuleResolvedByChannel, template.ResolvedBy)
+}
+
+func TestTemplateLookup_WithResolver(t *testing.T) {
+	t.Parallel()
+
+	fast := newModuleTemplate("fast", "v1.5.0")
+	regular := newModuleTemplate("regular", "v1.4.2")
+	clnt := newFakeClient(fast, regular)
+	resolver, err := channel.ParseResolver("stable=regular", "experimental->fast->regular,preview->beta")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		channel      string
+		wantTemplate string
+		wantErr      error
+	}{
+		{"alias", "stable", regular.Name, nil},
+		{"fallback", "experimental", fast.Name, nil},
+		{"channel with template", "fast", fast.Name, nil},
+		{"no template in chain", "preview", "", channel.ErrNoTemplatesInListResult},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			module := v1beta2.Module{Name: testModuleName, Channel: testCase.channel}
+			template := channel.NewTemplateLookup(clnt, module, v1beta2.DefaultChannel).
+				WithOptions(channel.LookupOptions{Resolver: resolver}).WithContext(context.Background())
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, template.Err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, template.Err)
+			assert.Equal(t, testCase.wantTemplate, template.GetName())
+			assert.Equal(t, testCase.channel, template.DesiredChannel)
+		})
+	}
+}
+
+func TestTemplateLookup_Indexed(t *testing.T) {
+	t.Parallel()
+
+	fast := newModuleTemplate("fast", "v1.5.0")
+	regular := newModuleTemplate("regular", "v1.4.2")
+	other := newModuleTemplateBuilder("regular", "v2.0.0").
+		WithName("other-module-regular").WithModuleName("other-module").Build()
+	descriptor, err := regular.GetDescriptor()
+	require.NoError(t, err)
+	indexedClient := newIndexedFakeClient(fast, regular, other)
+	listingClient := newFakeClient(fast, regular, other)
+
+	modules := []v1beta2.Module{
+		{Name: testModuleName},
+		{Name: testModuleName, Channel: "fast"},
+		{Name: testModuleName, Version: ">=1.0.0"},
+		{Name: other.Name},
+		{Name: regular.Namespace + "/" + regular.Name},
+		{Name: descriptor.Name, Channel: "fast"},
+		{Name: "unknown-module"},
+	}
+	for _, module := range modules {
+		opts := channel.LookupOptions{Indexed: true}
+		indexed := channel.NewTemplateLookup(indexedClient, module, v1beta2.DefaultChannel).
+			WithOptions(opts).WithContext(context.Background())
+		listed := channel.NewTemplateLookup(listingClient, module, v1beta2.DefaultChannel).
+			WithContext(context.Background())
+
+		assert.Equal(t, listed.Err != nil, indexed.Err != nil, "module %s", module.Name)
+		if listed.Err == nil && indexed.Err == nil {
+			assert.Equal(t, listed.GetName(), indexed.GetName(), "module %s", module.Name)
+		}
+	}
+}
+
+// BenchmarkTemplateLookup compares listing all ModuleTemplates with querying the indexes
+// for a catalog of many modules offered in several channels.
+func BenchmarkTemplateLookup(b *testing.B) {
+	const modules = 100
+	channels := []string{"experimental", "fast", "regular"}
+	templates := make([]*v1beta2.ModuleTemplate, 0, modules*len(channels))
+	for i := 0; i < modules; i++ {
+		for _, channelName := range channels {
+			templates = append(templates, newModuleTemplateBuilder(channelName, "v1.0.0").
+				WithName(fmt.Sprintf("module-%d-%s", i, channelName)).
+				WithModuleName(fmt.Sprintf("module-%d", i)).
+				Build())
+		}
+	}
+
+	benchmarks := []struct {
+		name   string
+		client client.Client
+		opts   channel.LookupOptions
+	}{
+		{"List", newFakeClient(templates...), channel.LookupOptions{}},
+		{"Indexed", newIndexedFakeClient(templates...), channel.LookupOptions{Indexed: true}},
+	}
+	for _, benchmark := range benchmarks {
+		benchmark := benchmark
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				module := v1beta2.Module{Name: fmt.Sprintf("module-%d", n%modules)}
+				template := channel.NewTemplateLookup(benchmark.client, module, v1beta2.DefaultChannel).
+					WithOptions(benchmark.opts).WithContext(context.Background())
+				if template.Err != nil {
+					b.Fatal(template.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckValidTemplateUpdate_Rollback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		rollbackVersion  string
+		wantErr          error
+		wantRollbackFrom string
+	}{
+		{"downgrade without annotation", "", channel.ErrTemplateUpdateNotAllowed, ""},
+		{"downgrade to annotated version", "1.4.2", nil, "v1.5.0"},
+		{"downgrade to other version", "1.4.1", channel.ErrTemplateUpdateNotAllowed, ""},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			templateBuilder := newModuleTemplateBuilder("regular", "v1.4.2").WithGeneration(3)
+			if testCase.rollbackVersion != "" {
+				templateBuilder = templateBuilder.WithAnnotation(v1beta2.RollbackVersionAnnotation,
+					testCase.rollbackVersion)
+			}
+			moduleTemplate := templateBuilder.Build()
+			template := &channel.ModuleTemplateTO{ModuleTemplate: moduleTemplate}
+			moduleStatus := &v1beta2.ModuleStatus{
+				Channel: "regular",
+				Version: "v1.5.0",
+				Template: &v1beta2.TrackingObject{
+					PartialMeta: v1beta2.PartialMeta{Name: moduleTemplate.Name, Generation: 2},
+				},
+			}
+
+			channel.CheckValidTemplateUpdate(logr.Discard(), template, moduleStatus)
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, template.Err, testCase.wantErr)
+			} else {
+				require.NoError(t, template.Err)
+			}
+			assert.Equal(t, testCase.wantRollbackFrom, template.RollbackFrom)
+		})
+	}
+}
+
+func TestDetermineTemplatesVisibility_Audience(t *testing.T) {
+	t.Parallel()
+
+	kyma := builder.NewKymaBuilder().WithLabel("region", "eu-west").Build()
+	visible := newModuleTemplateBuilder("regular", "v1.0.0").
+		WithAudience(&metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu-west"}}).Build()
+	hidden := newModuleTemplateBuilder("regular", "v1.0.0").
+		WithAudience(&metav1.LabelSelector{MatchLabels: map[string]string{"region": "us-east"}}).Build()
+	templates := channel.ModuleTemplatesByModuleName{
+		"visible": {ModuleTemplate: visible},
+		"hidden":  {ModuleTemplate: hidden},
+	}
+
+	channel.DetermineTemplatesVisibility(kyma, templates)
+
+	require.NoError(t, templates["visible"].Err)
+	require.ErrorIs(t, templates["hidden"].Err, channel.ErrTemplateNotAllowed)
+	assert.Contains(t, templates["hidden"].Err.Error(), "region=us-east")
+}
+
+func TestDetermineTemplatesVisibility_InvalidAudience(t *testing.T) {
+	t.Parallel()
+
+	kyma := builder.NewKymaBuilder().WithLabel("region", "eu-west").Build()
+	invalid := newModuleTemplateBuilder("regular", "v1.0.0").
+		WithAudience(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "region", Operator: "Unknown", Values: []string{"eu-west"}},
+		}}).Build()
+	templates := channel.ModuleTemplatesByModuleName{"invalid": {ModuleTemplate: invalid}}
+
+	channel.DetermineTemplatesVisibility(kyma, templates)
+
+	require.ErrorIs(t, templates["invalid"].Err, channel.ErrInvalidAudience)
+	assert.NotErrorIs(t, templates["invalid"].Err, channel.ErrTemplateNotAllowed,
+		"an invalid audience must not uninstall the module")
+}
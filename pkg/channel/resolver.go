@@ -0,0 +1,108 @@
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidChannelResolution = errors.New("invalid channel resolution")
+
+const (
+	aliasSeparator    = "="
+	fallbackSeparator = "->"
+	listSeparator     = ","
+)
+
+// Resolver resolves a desired channel into the chain of channels that are looked up for a ModuleTemplate.
+// An alias names another channel, e.g. stable = regular, and a fallback names the channel that is looked up
+// next if a channel offers no ModuleTemplate for a module, e.g. experimental -> fast -> regular.
+// A nil Resolver resolves every channel to itself only.
+type Resolver struct {
+	aliases   map[string]string
+	fallbacks map[string]string
+}
+
+// NewResolver validates that aliases do not point to other aliases and that fallbacks do not form a cycle.
+func NewResolver(aliases, fallbacks map[string]string) (*Resolver, error) {
+	for alias, channel := range aliases {
+		if _, isAlias := aliases[channel]; isAlias {
+			return nil, fmt.Errorf("%w: alias %s points to alias %s", ErrInvalidChannelResolution, alias, channel)
+		}
+	}
+	for channel := range fallbacks {
+		seen := map[string]bool{channel: true}
+		for next, found := fallbacks[channel]; found; next, found = fallbacks[next] {
+			if seen[next] {
+				return nil, fmt.Errorf("%w: fallback of channel %s is cyclic", ErrInvalidChannelResolution, channel)
+			}
+			seen[next] = true
+		}
+	}
+	return &Resolver{aliases: aliases, fallbacks: fallbacks}, nil
+}
+
+// ParseResolver creates a Resolver from a comma-separated list of aliases like "stable=regular" and
+// a comma-separated list of fallback chains like "experimental->fast->regular".
+func ParseResolver(aliasList, fallbackList string) (*Resolver, error) {
+	aliases := make(map[string]string)
+	for _, entry := range splitList(aliasList) {
+		alias, channel, found := strings.Cut(entry, aliasSeparator)
+		alias, channel = strings.TrimSpace(alias), strings.TrimSpace(channel)
+		if !found || alias == "" || channel == "" || alias == channel {
+			return nil, fmt.Errorf("%w: alias %q is not of the form alias=channel", ErrInvalidChannelResolution, entry)
+		}
+		if existing, defined := aliases[alias]; defined && existing != channel {
+			return nil, fmt.Errorf("%w: alias %s is defined more than once", ErrInvalidChannelResolution, alias)
+		}
+		aliases[alias] = channel
+	}
+
+	fallbacks := make(map[string]string)
+	for _, entry := range splitList(fallbackList) {
+		if !strings.Contains(entry, fallbackSeparator) {
+			return nil, fmt.Errorf("%w: fallback %q is not of the form channel->fallback",
+				ErrInvalidChannelResolution, entry)
+		}
+		chain := strings.Split(entry, fallbackSeparator)
+		for i := 0; i < len(chain)-1; i++ {
+			channel, fallback := strings.TrimSpace(chain[i]), strings.TrimSpace(chain[i+1])
+			if channel == "" || fallback == "" {
+				return nil, fmt.Errorf("%w: fallback %q contains an empty channel", ErrInvalidChannelResolution, entry)
+			}
+			if existing, defined := fallbacks[channel]; defined && existing != fallback {
+				return nil, fmt.Errorf("%w: channel %s falls back to both %s and %s",
+					ErrInvalidChannelResolution, channel, existing, fallback)
+			}
+			fallbacks[channel] = fallback
+		}
+	}
+	return NewResolver(aliases, fallbacks)
+}
+
+// Chain returns the channels to look up for the desired channel, in order.
+// The first channel is the desired channel with its alias resolved.
+func (r *Resolver) Chain(desiredChannel string) []string {
+	if r == nil {
+		return []string{desiredChannel}
+	}
+	channel := desiredChannel
+	if aliased, isAlias := r.aliases[channel]; isAlias {
+		channel = aliased
+	}
+	chain := []string{channel}
+	for next, found := r.fallbacks[channel]; found; next, found = r.fallbacks[next] {
+		chain = append(chain, next)
+	}
+	return chain
+}
+
+func splitList(list string) []string {
+	var entries []string
+	for _, entry := range strings.Split(list, listSeparator) {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,55 @@
+package channel_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+)
+
+func TestParseResolver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		aliases   string
+		fallbacks string
+		desired   string
+		wantChain []string
+		wantErr   bool
+	}{
+		{"no resolution", "", "", "regular", []string{"regular"}, false},
+		{"alias", "stable=regular", "", "stable", []string{"regular"}, false},
+		{
+			"fallback chain", "", "experimental->fast->regular", "experimental",
+			[]string{"experimental", "fast", "regular"}, false,
+		},
+		{"alias with fallback", "edge=fast", "fast -> regular", "edge", []string{"fast", "regular"}, false},
+		{"alias to alias", "stable=regular,edge=stable", "", "", nil, true},
+		{"cyclic fallback", "", "fast->regular,regular->fast", "", nil, true},
+		{"conflicting fallback", "", "fast->regular,fast->experimental", "", nil, true},
+		{"malformed alias", "stable", "", "", nil, true},
+		{"malformed fallback", "", "fast", "", nil, true},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			resolver, err := channel.ParseResolver(testCase.aliases, testCase.fallbacks)
+			if testCase.wantErr {
+				require.ErrorIs(t, err, channel.ErrInvalidChannelResolution)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantChain, resolver.Chain(testCase.desired))
+		})
+	}
+}
+
+func TestResolver_NilResolvesChannelToItself(t *testing.T) {
+	t.Parallel()
+	var resolver *channel.Resolver
+	assert.Equal(t, []string{"fast"}, resolver.Chain("fast"))
+}
@@ -0,0 +1,187 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+var ErrModuleSourceUnavailable = errors.New("module source unavailable")
+
+// sourceErrorTTL bounds how long a CachedSource keeps the failure of its source, so that an unavailable
+// source is not queried on every lookup, but is queried again soon.
+const sourceErrorTTL = 30 * time.Second
+
+// ModuleSource provides the ModuleTemplates a TemplateLookup resolves modules from. Besides the ModuleTemplates
+// in the cluster, modules can be resolved from further sources configured in LookupOptions.Sources, which
+// offer ModuleTemplates that are not materialized as resources, e.g. an OCMSource or a FileSource.
+type ModuleSource interface {
+	// ListTemplates lists the candidates for the ModuleTemplate of the module, optionally restricted to a channel.
+	// Candidates are filtered by the TemplateLookup, so a source may return ModuleTemplates of other modules.
+	// An empty moduleName lists the ModuleTemplates of all modules offered by the source.
+	ListTemplates(ctx context.Context, moduleName, channel string) ([]v1beta2.ModuleTemplate, error)
+}
+
+// ClusterSource lists the ModuleTemplates in the cluster of the reader.
+type ClusterSource struct {
+	reader  client.Reader
+	indexed bool
+}
+
+// NewClusterSource creates a ClusterSource that queries the indexes registered with IndexModuleTemplates
+// if indexed is true. For indexes, the reader has to be backed by the cache the indexes are registered with.
+func NewClusterSource(reader client.Reader, indexed bool) *ClusterSource {
+	return &ClusterSource{reader: reader, indexed: indexed}
+}
+
+// ListTemplates lists all ModuleTemplates without indexes. With indexes, the candidates are the ModuleTemplates
+// indexed by the module name label or descriptor name of the module. Only if there are none, the ModuleTemplates
+// named like the module are candidates.
+func (s *ClusterSource) ListTemplates(ctx context.Context, moduleName, channel string) (
+	[]v1beta2.ModuleTemplate, error,
+) {
+	if !s.indexed || moduleName == "" {
+		templateList := &v1beta2.ModuleTemplateList{}
+		if err := s.reader.List(ctx, templateList); err != nil {
+			return nil, fmt.Errorf("failed to list module templates on lookup: %w", err)
+		}
+		return templateList.Items, nil
+	}
+
+	var templates []v1beta2.ModuleTemplate
+	listed := make(map[string]bool)
+	for _, index := range []string{ModuleNameIndex, DescriptorNameIndex} {
+		templateList := &v1beta2.ModuleTemplateList{}
+		if err := s.reader.List(ctx, templateList, client.MatchingFields{index: moduleName}); err != nil {
+			return nil, fmt.Errorf("failed to list module templates by %s on lookup: %w", index, err)
+		}
+		for i := range templateList.Items {
+			template := &templateList.Items[i]
+			key := client.ObjectKeyFromObject(template).String()
+			if listed[key] || (channel != "" && template.Spec.Channel != channel) {
+				continue
+			}
+			listed[key] = true
+			templates = append(templates, *template)
+		}
+	}
+	if len(templates) > 0 {
+		return templates, nil
+	}
+
+	templateList := &v1beta2.ModuleTemplateList{}
+	var opts []client.ListOption
+	if channel != "" {
+		opts = append(opts, client.MatchingFields{ChannelIndex: channel})
+	}
+	if err := s.reader.List(ctx, templateList, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list module templates by %s on lookup: %w", ChannelIndex, err)
+	}
+	for i := range templateList.Items {
+		template := &templateList.Items[i]
+		if template.GetName() == moduleName || client.ObjectKeyFromObject(template).String() == moduleName {
+			templates = append(templates, *template)
+		}
+	}
+	return templates, nil
+}
+
+// ListAllTemplates lists the ModuleTemplates of all modules in the cluster of the reader and in the Sources
+// of the options. If a source is unavailable, the ModuleTemplates of the others are listed nonetheless,
+// together with an error wrapping ErrModuleSourceUnavailable.
+func ListAllTemplates(ctx context.Context, reader client.Reader, opts LookupOptions) (
+	[]v1beta2.ModuleTemplate, error,
+) {
+	return listFromSources(ctx, NewClusterSource(reader, false), opts.Sources, "", "")
+}
+
+// listFromSources fails if the cluster cannot be listed. A source that cannot be listed does not hide
+// the ModuleTemplates of the cluster and the other sources, instead, it is reported in an error
+// wrapping ErrModuleSourceUnavailable.
+func listFromSources(ctx context.Context, cluster ModuleSource, sources []ModuleSource, moduleName, channel string) (
+	[]v1beta2.ModuleTemplate, error,
+) {
+	templates, err := cluster.ListTemplates(ctx, moduleName, channel)
+	if err != nil {
+		return nil, err
+	}
+	var sourceErrs []error
+	for _, source := range sources {
+		sourceTemplates, err := source.ListTemplates(ctx, moduleName, channel)
+		if err != nil {
+			sourceErrs = append(sourceErrs, fmt.Errorf("%w: %s: %w", ErrModuleSourceUnavailable, sourceName(source), err))
+			continue
+		}
+		templates = append(templates, sourceTemplates...)
+	}
+	return templates, errors.Join(sourceErrs...)
+}
+
+// sourceName names a source in errors by its String method, if it has one.
+func sourceName(source ModuleSource) string {
+	if stringer, ok := source.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%T", source)
+}
+
+type cachedTemplates struct {
+	templates []v1beta2.ModuleTemplate
+	err       error
+	expiresAt time.Time
+}
+
+// CachedSource keeps the ModuleTemplates listed by a ModuleSource in memory, so that a source backed by
+// a remote repository is not queried on every lookup. Failures of the source are kept as well, but only
+// for a short time.
+type CachedSource struct {
+	source ModuleSource
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedTemplates
+}
+
+// NewCachedSource caches the ModuleTemplates listed by the source for the given time to live.
+func NewCachedSource(source ModuleSource, ttl time.Duration) *CachedSource {
+	return &CachedSource{source: source, ttl: ttl, cache: make(map[string]cachedTemplates)}
+}
+
+func (s *CachedSource) String() string {
+	return sourceName(s.source)
+}
+
+func (s *CachedSource) ListTemplates(ctx context.Context, moduleName, channel string) (
+	[]v1beta2.ModuleTemplate, error,
+) {
+	key := moduleName + "/" + channel
+	s.mu.Lock()
+	cached, found := s.cache[key]
+	s.mu.Unlock()
+	if !found || !time.Now().Before(cached.expiresAt) {
+		templates, err := s.source.ListTemplates(ctx, moduleName, channel)
+		ttl := s.ttl
+		if err != nil {
+			ttl = min(ttl, sourceErrorTTL)
+		}
+		cached = cachedTemplates{templates: templates, err: err, expiresAt: time.Now().Add(ttl)}
+		s.mu.Lock()
+		s.cache[key] = cached
+		s.mu.Unlock()
+	}
+	if cached.err != nil {
+		return nil, cached.err
+	}
+	// the lookup decodes the descriptors into the ModuleTemplates, so every caller gets its own copy.
+	templates := make([]v1beta2.ModuleTemplate, len(cached.templates))
+	for i := range cached.templates {
+		cached.templates[i].DeepCopyInto(&templates[i])
+	}
+	return templates, nil
+}
@@ -0,0 +1,86 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+const decoderBufferSize = 2048
+
+// FileSource offers the ModuleTemplates in the YAML or JSON files of a directory, which can contain
+// several ModuleTemplates each, e.g. to resolve modules in tests without a cluster. The files are read on every
+// lookup, so outside of tests it is wrapped in a CachedSource.
+type FileSource struct {
+	dir string
+}
+
+func NewFileSource(dir string) *FileSource {
+	return &FileSource{dir: dir}
+}
+
+func (s *FileSource) String() string {
+	return "directory " + s.dir
+}
+
+func (s *FileSource) ListTemplates(_ context.Context, _, channel string) ([]v1beta2.ModuleTemplate, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module templates from %s: %w", s.dir, err)
+	}
+	var templates []v1beta2.ModuleTemplate
+	for _, entry := range entries {
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+		fileTemplates, err := readTemplateFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, template := range fileTemplates {
+			if channel == "" || template.Spec.Channel == channel {
+				templates = append(templates, template)
+			}
+		}
+	}
+	return templates, nil
+}
+
+func readTemplateFile(path string) ([]v1beta2.ModuleTemplate, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module templates from %s: %w", path, err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewYAMLOrJSONDecoder(file, decoderBufferSize)
+	var templates []v1beta2.ModuleTemplate
+	for {
+		template := v1beta2.ModuleTemplate{}
+		err := decoder.Decode(&template)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode module template from %s: %w", path, err)
+		}
+		// other resources and empty documents are skipped.
+		if template.Kind != string(v1beta2.ModuleTemplateKind) {
+			continue
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
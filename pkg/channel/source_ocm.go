@@ -0,0 +1,159 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+// OCMChannelsLabel is the label of a component version that lists the channels the version is offered in,
+// e.g. ["regular", "fast"].
+const OCMChannelsLabel = v1beta2.OperatorPrefix + v1beta2.Separator + "channels"
+
+var ErrInvalidOCMComponents = errors.New("invalid OCM components")
+
+// OCMSource offers modules from the component versions in an OCM component repository, e.g. an OCI registry,
+// without ModuleTemplates for them in the cluster. Each channel offers the highest version of a component
+// that lists the channel in its OCMChannelsLabel. As every lookup queries the repository, the source
+// is usually wrapped in a CachedSource.
+type OCMSource struct {
+	repository ocm.Repository
+	// components maps the module names to the names of their components in the repository.
+	components map[string]string
+}
+
+func NewOCMSource(repository ocm.Repository, components map[string]string) *OCMSource {
+	return &OCMSource{repository: repository, components: components}
+}
+
+func (s *OCMSource) String() string {
+	return "OCM repository"
+}
+
+// ParseOCMComponents parses a comma-separated list of modules and their components like
+// "btp-operator=kyma-project.io/module/btp-operator".
+func ParseOCMComponents(componentList string) (map[string]string, error) {
+	components := make(map[string]string)
+	for _, entry := range splitList(componentList) {
+		moduleName, component, found := strings.Cut(entry, aliasSeparator)
+		moduleName, component = strings.TrimSpace(moduleName), strings.TrimSpace(component)
+		if !found || moduleName == "" || component == "" {
+			return nil, fmt.Errorf("%w: %q is not of the form module=component", ErrInvalidOCMComponents, entry)
+		}
+		if _, defined := components[moduleName]; defined {
+			return nil, fmt.Errorf("%w: module %s is defined more than once", ErrInvalidOCMComponents, moduleName)
+		}
+		components[moduleName] = component
+	}
+	return components, nil
+}
+
+// ListTemplates only queries the repository for the component of the module, which can be identified
+// by its module name or component name.
+func (s *OCMSource) ListTemplates(_ context.Context, moduleName, channel string) (
+	[]v1beta2.ModuleTemplate, error,
+) {
+	var templates []v1beta2.ModuleTemplate
+	for module, component := range s.components {
+		if moduleName != "" && moduleName != module && moduleName != component {
+			continue
+		}
+		componentTemplates, err := s.listComponentTemplates(module, component)
+		if err != nil {
+			return nil, err
+		}
+		for _, template := range componentTemplates {
+			if channel == "" || template.Spec.Channel == channel {
+				templates = append(templates, template)
+			}
+		}
+	}
+	return templates, nil
+}
+
+func (s *OCMSource) listComponentTemplates(moduleName, componentName string) ([]v1beta2.ModuleTemplate, error) {
+	component, err := s.repository.LookupComponent(componentName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up component %s: %w", componentName, err)
+	}
+	defer component.Close()
+	versions, err := component.ListVersions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions of component %s: %w", componentName, err)
+	}
+
+	latestByChannel := make(map[string]*compdesc.ComponentDescriptor)
+	for _, version := range versions {
+		descriptor, err := lookupDescriptor(component, version)
+		if err != nil {
+			return nil, err
+		}
+		var channels []string
+		if _, err := descriptor.Labels.GetValue(OCMChannelsLabel, &channels); err != nil {
+			return nil, fmt.Errorf("invalid label %s of component %s:%s: %w",
+				OCMChannelsLabel, componentName, version, err)
+		}
+		for _, channel := range channels {
+			if latest, found := latestByChannel[channel]; !found || versionLess(latest.Version, descriptor.Version) {
+				latestByChannel[channel] = descriptor
+			}
+		}
+	}
+
+	templates := make([]v1beta2.ModuleTemplate, 0, len(latestByChannel))
+	for channel, descriptor := range latestByChannel {
+		template, err := newTemplateFromDescriptor(moduleName, channel, descriptor)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *template)
+	}
+	return templates, nil
+}
+
+func lookupDescriptor(component ocm.ComponentAccess, version string) (*compdesc.ComponentDescriptor, error) {
+	componentVersion, err := component.LookupVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up version %s of component %s: %w", version, component.GetName(), err)
+	}
+	defer componentVersion.Close()
+	return componentVersion.GetDescriptor().Copy(), nil
+}
+
+// newTemplateFromDescriptor creates the ModuleTemplate a component version offers in a channel. It is named
+// like the ModuleTemplates of a module are usually named, and has no Data, so no module CR is created for it.
+func newTemplateFromDescriptor(moduleName, channel string, descriptor *compdesc.ComponentDescriptor) (
+	*v1beta2.ModuleTemplate, error,
+) {
+	raw, err := compdesc.Encode(descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode descriptor of component %s:%s: %w",
+			descriptor.Name, descriptor.Version, err)
+	}
+	return &v1beta2.ModuleTemplate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta2.GroupVersion.String(),
+			Kind:       string(v1beta2.ModuleTemplateKind),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s", moduleName, channel),
+			Labels:      map[string]string{v1beta2.ModuleName: moduleName},
+			Annotations: map[string]string{v1beta2.ModuleVersionAnnotation: descriptor.Version},
+		},
+		Spec: v1beta2.ModuleTemplateSpec{
+			Channel: channel,
+			Descriptor: runtime.RawExtension{
+				Raw:    raw,
+				Object: &v1beta2.Descriptor{ComponentDescriptor: descriptor},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,117 @@
+package channel_test
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/virtual/example"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+)
+
+const testComponentName = "kyma-project.io/module/test-module"
+
+// newOCMRepository creates a repository offering the component versions with the given channels labels.
+func newOCMRepository(t *testing.T, channelsByVersion map[string]any) ocm.Repository {
+	t.Helper()
+	fs := memoryfs.New()
+	require.NoError(t, fs.MkdirAll("descriptors", 0o700))
+	for version, channels := range channelsByVersion {
+		descriptor := compdesc.New(testComponentName, version)
+		require.NoError(t, descriptor.Labels.Set(channel.OCMChannelsLabel, channels))
+		raw, err := compdesc.Encode(descriptor)
+		require.NoError(t, err)
+		require.NoError(t, vfs.WriteFile(fs, path.Join("descriptors", version), raw, 0o600))
+	}
+	repository, err := example.NewRepository(ocm.DefaultContext(), fs, true)
+	require.NoError(t, err)
+	return repository
+}
+
+func TestOCMSource_ListTemplates(t *testing.T) {
+	t.Parallel()
+
+	source := channel.NewOCMSource(newOCMRepository(t, map[string]any{
+		"1.0.0": []string{"regular", "fast"},
+		"1.1.0": []string{"fast"},
+		"1.2.0": []string{},
+	}), map[string]string{testModuleName: testComponentName})
+
+	tests := []struct {
+		name         string
+		moduleName   string
+		channel      string
+		wantVersions map[string]string
+	}{
+		{"latest version per channel", testModuleName, "", map[string]string{"regular": "1.0.0", "fast": "1.1.0"}},
+		{"channel", testModuleName, "regular", map[string]string{"regular": "1.0.0"}},
+		{"component name", testComponentName, "fast", map[string]string{"fast": "1.1.0"}},
+		{"channel without version", testModuleName, "alpha", map[string]string{}},
+		{"other module", "other-module", "", map[string]string{}},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			templates, err := source.ListTemplates(context.Background(), testCase.moduleName, testCase.channel)
+			require.NoError(t, err)
+
+			versions := make(map[string]string)
+			for _, template := range templates {
+				assert.Equal(t, testModuleName+"-"+template.Spec.Channel, template.Name)
+				assert.Equal(t, testModuleName, template.Labels[v1beta2.ModuleName])
+				descriptor, err := template.GetDescriptor()
+				require.NoError(t, err)
+				assert.Equal(t, testComponentName, descriptor.Name)
+				versions[template.Spec.Channel] = descriptor.Version
+			}
+			assert.Equal(t, testCase.wantVersions, versions)
+		})
+	}
+}
+
+func TestOCMSource_InvalidChannelsLabel(t *testing.T) {
+	t.Parallel()
+
+	source := channel.NewOCMSource(newOCMRepository(t, map[string]any{"1.0.0": "regular"}),
+		map[string]string{testModuleName: testComponentName})
+
+	_, err := source.ListTemplates(context.Background(), testModuleName, "regular")
+	require.ErrorContains(t, err, "invalid label "+channel.OCMChannelsLabel)
+}
+
+func TestTemplateLookup_OCMSource(t *testing.T) {
+	t.Parallel()
+
+	source := channel.NewOCMSource(newOCMRepository(t, map[string]any{"1.0.0": []string{"regular"}}),
+		map[string]string{testModuleName: testComponentName})
+
+	template := channel.NewTemplateLookup(newFakeClient(), v1beta2.Module{Name: testModuleName}, "regular").
+		WithOptions(channel.LookupOptions{Sources: []channel.ModuleSource{source}}).
+		WithContext(context.Background())
+
+	require.NoError(t, template.Err)
+	assert.Equal(t, "regular", template.Spec.Channel)
+	assert.Equal(t, "1.0.0", template.Annotations[v1beta2.ModuleVersionAnnotation])
+}
+
+func TestParseOCMComponents(t *testing.T) {
+	t.Parallel()
+
+	components, err := channel.ParseOCMComponents(" test-module = " + testComponentName + ",other=other-component")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{testModuleName: testComponentName, "other": "other-component"}, components)
+
+	for _, invalid := range []string{"test-module", "=component", "test-module=a,test-module=b"} {
+		_, err := channel.ParseOCMComponents(invalid)
+		require.ErrorIs(t, err, channel.ErrInvalidOCMComponents, invalid)
+	}
+}
@@ -0,0 +1,190 @@
+package channel_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+)
+
+var errSourceUnavailable = errors.New("source unavailable")
+
+func writeTemplateFile(t *testing.T, dir, name string, templates ...*v1beta2.ModuleTemplate) {
+	t.Helper()
+	var content []byte
+	for _, template := range templates {
+		template.Kind = string(v1beta2.ModuleTemplateKind)
+		raw, err := yaml.Marshal(template)
+		require.NoError(t, err)
+		content = append(append(content, "---\n"...), raw...)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o600))
+}
+
+func TestTemplateLookup_FileSource(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTemplateFile(t, dir, "templates.yaml",
+		newModuleTemplate("regular", "v1.0.0"), newModuleTemplate("fast", "v1.1.0"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a template"), 0o600))
+	opts := channel.LookupOptions{Sources: []channel.ModuleSource{channel.NewFileSource(dir)}}
+
+	tests := []struct {
+		name        string
+		module      v1beta2.Module
+		wantVersion string
+		wantErr     error
+	}{
+		{"channel", v1beta2.Module{Name: testModuleName, Channel: "fast"}, "v1.1.0", nil},
+		{"version pin", v1beta2.Module{Name: testModuleName, Version: "~1.0.0"}, "v1.0.0", nil},
+		{
+			"channel without template", v1beta2.Module{Name: testModuleName, Channel: "alpha"},
+			"", channel.ErrNoTemplatesInListResult,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			template := channel.NewTemplateLookup(newFakeClient(), testCase.module, v1beta2.DefaultChannel).
+				WithOptions(opts).
+				WithContext(context.Background())
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, template.Err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, template.Err)
+			descriptor, err := template.GetDescriptor()
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantVersion, descriptor.Version)
+		})
+	}
+}
+
+func TestTemplateLookup_SourceConflictsWithCluster(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTemplateFile(t, dir, "regular.yaml", newModuleTemplate("regular", "v1.0.0"))
+	module := v1beta2.Module{Name: testModuleName, Channel: "regular"}
+
+	template := channel.NewTemplateLookup(newFakeClient(newModuleTemplate("regular", "v1.0.1")), module,
+		v1beta2.DefaultChannel).
+		WithOptions(channel.LookupOptions{Sources: []channel.ModuleSource{channel.NewFileSource(dir)}}).
+		WithContext(context.Background())
+
+	require.ErrorIs(t, template.Err, channel.ErrTemplateNotIdentified)
+}
+
+type countingSource struct {
+	calls int
+}
+
+func (s *countingSource) ListTemplates(_ context.Context, _, channelName string) ([]v1beta2.ModuleTemplate, error) {
+	s.calls++
+	return []v1beta2.ModuleTemplate{*newModuleTemplate(channelName, "v1.0.0")}, nil
+}
+
+func TestCachedSource(t *testing.T) {
+	t.Parallel()
+
+	source := &countingSource{}
+	cached := channel.NewCachedSource(source, time.Hour)
+
+	first, err := cached.ListTemplates(context.Background(), testModuleName, "regular")
+	require.NoError(t, err)
+	_, err = first[0].GetDescriptor()
+	require.NoError(t, err)
+	second, err := cached.ListTemplates(context.Background(), testModuleName, "regular")
+	require.NoError(t, err)
+	_, err = cached.ListTemplates(context.Background(), testModuleName, "fast")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, source.calls, "a module and channel is only listed once")
+	assert.Nil(t, second[0].Spec.Descriptor.Object, "cached templates are not changed by their callers")
+
+	expired := channel.NewCachedSource(source, 0)
+	_, err = expired.ListTemplates(context.Background(), testModuleName, "regular")
+	require.NoError(t, err)
+	_, err = expired.ListTemplates(context.Background(), testModuleName, "regular")
+	require.NoError(t, err)
+	assert.Equal(t, 4, source.calls, "expired templates are listed again")
+}
+
+type failingSource struct {
+	calls int
+}
+
+func (s *failingSource) String() string {
+	return "failing source"
+}
+
+func (s *failingSource) ListTemplates(context.Context, string, string) ([]v1beta2.ModuleTemplate, error) {
+	s.calls++
+	return nil, errSourceUnavailable
+}
+
+func TestTemplateLookup_UnavailableSource(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTemplateFile(t, dir, "templates.yaml", newModuleTemplate("fast", "v1.1.0"))
+	opts := channel.LookupOptions{
+		Sources: []channel.ModuleSource{&failingSource{}, channel.NewFileSource(dir)},
+	}
+	lookup := func(module v1beta2.Module) channel.ModuleTemplateTO {
+		return channel.NewTemplateLookup(newFakeClient(newModuleTemplate("regular", "v1.0.0")), module,
+			v1beta2.DefaultChannel).WithOptions(opts).WithContext(context.Background())
+	}
+
+	template := lookup(v1beta2.Module{Name: testModuleName, Channel: "regular"})
+	require.NoError(t, template.Err, "the cluster is still available")
+	template = lookup(v1beta2.Module{Name: testModuleName, Channel: "fast"})
+	require.NoError(t, template.Err, "other sources are still available")
+	template = lookup(v1beta2.Module{Name: testModuleName, Version: "~1.1.0"})
+	require.NoError(t, template.Err, "other sources are still available")
+
+	template = lookup(v1beta2.Module{Name: testModuleName, Channel: "alpha"})
+	require.ErrorIs(t, template.Err, channel.ErrModuleSourceUnavailable,
+		"a module is not considered missing while a source is unavailable")
+	require.NotErrorIs(t, template.Err, channel.ErrNoTemplatesInListResult)
+	assert.Contains(t, template.Err.Error(), "failing source")
+
+	templates, err := channel.ListAllTemplates(context.Background(),
+		newFakeClient(newModuleTemplate("regular", "v1.0.0")), opts)
+	require.ErrorIs(t, err, errSourceUnavailable)
+	require.ErrorIs(t, err, channel.ErrModuleSourceUnavailable)
+	assert.Len(t, templates, 2)
+}
+
+func TestCachedSource_Failure(t *testing.T) {
+	t.Parallel()
+
+	source := &failingSource{}
+	cached := channel.NewCachedSource(source, time.Hour)
+
+	_, err := cached.ListTemplates(context.Background(), testModuleName, "regular")
+	require.ErrorIs(t, err, errSourceUnavailable)
+	_, err = cached.ListTemplates(context.Background(), testModuleName, "regular")
+	require.ErrorIs(t, err, errSourceUnavailable)
+	assert.Equal(t, 1, source.calls, "a failure is cached as well")
+	assert.Equal(t, "failing source", cached.String())
+
+	expired := channel.NewCachedSource(source, 0)
+	_, err = expired.ListTemplates(context.Background(), testModuleName, "regular")
+	require.ErrorIs(t, err, errSourceUnavailable)
+	_, err = expired.ListTemplates(context.Background(), testModuleName, "regular")
+	require.ErrorIs(t, err, errSourceUnavailable)
+	assert.Equal(t, 3, source.calls, "an expired failure is listed again")
+}
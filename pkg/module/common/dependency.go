@@ -0,0 +1,150 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+var (
+	ErrDependencyMissing  = errors.New("module dependency is not part of the kyma")
+	ErrDependencyCycle    = errors.New("module dependency cycle detected")
+	ErrDependencyNotReady = errors.New("module dependency is not ready")
+)
+
+// Dependencies returns the module identifiers this module depends on as declared in its ModuleTemplate.
+func (m *Module) Dependencies() []string {
+	if m.Template == nil || m.Template.ModuleTemplate == nil {
+		return nil
+	}
+	return m.Template.Spec.Dependencies
+}
+
+// DependencyNames returns the names of the resolved dependencies of the module.
+func (m *Module) DependencyNames() []string {
+	names := make([]string, 0, len(m.DependsOn))
+	for _, dependency := range m.DependsOn {
+		names = append(names, dependency.ModuleName)
+	}
+	return names
+}
+
+// CheckDependenciesReady returns ErrDependencyNotReady if any resolved dependency of the module
+// does not have a Ready Manifest yet.
+func (m *Module) CheckDependenciesReady() error {
+	for _, dependency := range m.DependsOn {
+		if dependency.Template.Err != nil {
+			return fmt.Errorf("%w: %s has errors", ErrDependencyNotReady, dependency.ModuleName)
+		}
+		manifest, ok := dependency.Object.(*v1beta2.Manifest)
+		if !ok || manifest.Status.State != shared.StateReady {
+			return fmt.Errorf("%w: waiting for %s to become %s", ErrDependencyNotReady,
+				dependency.ModuleName, shared.StateReady)
+		}
+	}
+	return nil
+}
+
+func (m *Module) identifiers() []string {
+	identifiers := []string{m.ModuleName, m.FQDN}
+	if m.Template != nil && m.Template.ModuleTemplate != nil {
+		identifiers = append(identifiers, m.Template.GetLabels()[v1beta2.ModuleName])
+	}
+	return identifiers
+}
+
+// ResolveDependencies resolves the dependencies of all modules against each other and groups the modules
+// into levels, so that every module only depends on modules of previous levels.
+// Modules with a missing or cyclic dependency get an error set on their template.
+func ResolveDependencies(modules Modules) []Modules {
+	index := make(map[string]*Module, len(modules))
+	for _, module := range modules {
+		for _, identifier := range module.identifiers() {
+			if identifier != "" {
+				index[identifier] = module
+			}
+		}
+	}
+
+	for _, module := range modules {
+		module.DependsOn = nil
+		for _, identifier := range module.Dependencies() {
+			dependency, found := index[identifier]
+			if !found {
+				setDependencyErr(module, fmt.Errorf("%w: %s", ErrDependencyMissing, identifier))
+				continue
+			}
+			module.DependsOn = append(module.DependsOn, dependency)
+		}
+	}
+
+	resolver := &levelResolver{levels: make(map[*Module]int), visiting: make(map[*Module]bool)}
+	var levels []Modules
+	for _, module := range modules {
+		level := resolver.level(module)
+		for len(levels) <= level {
+			levels = append(levels, Modules{})
+		}
+		levels[level] = append(levels[level], module)
+	}
+	return levels
+}
+
+type levelResolver struct {
+	levels   map[*Module]int
+	visiting map[*Module]bool
+	stack    Modules
+}
+
+func (r *levelResolver) level(module *Module) int {
+	if level, done := r.levels[module]; done {
+		return level
+	}
+	if r.visiting[module] {
+		r.markCycle(module)
+		return 0
+	}
+
+	r.visiting[module] = true
+	r.stack = append(r.stack, module)
+	level := 0
+	for _, dependency := range module.DependsOn {
+		if dependencyLevel := r.level(dependency) + 1; dependencyLevel > level {
+			level = dependencyLevel
+		}
+	}
+	r.stack = r.stack[:len(r.stack)-1]
+	delete(r.visiting, module)
+
+	r.levels[module] = level
+	return level
+}
+
+func (r *levelResolver) markCycle(module *Module) {
+	start := 0
+	for i, visited := range r.stack {
+		if visited == module {
+			start = i
+			break
+		}
+	}
+	cycle := r.stack[start:]
+	names := make([]string, 0, len(cycle)+1)
+	for _, visited := range cycle {
+		names = append(names, visited.ModuleName)
+	}
+	names = append(names, module.ModuleName)
+	err := fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(names, " -> "))
+	for _, visited := range cycle {
+		setDependencyErr(visited, err)
+	}
+}
+
+func setDependencyErr(module *Module, err error) {
+	if module.Template != nil && module.Template.Err == nil {
+		module.Template.Err = err
+	}
+}
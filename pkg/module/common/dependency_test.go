@@ -0,0 +1,93 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/common"
+)
+
+func newModule(name string, dependencies ...string) *common.Module {
+	template := &v1beta2.ModuleTemplate{}
+	template.SetLabels(map[string]string{v1beta2.ModuleName: name})
+	template.Spec.Dependencies = dependencies
+	return &common.Module{
+		ModuleName: name,
+		FQDN:       "kyma-project.io/module/" + name,
+		Template:   &channel.ModuleTemplateTO{ModuleTemplate: template},
+		Object:     &v1beta2.Manifest{},
+	}
+}
+
+func levelNames(levels []common.Modules) [][]string {
+	names := make([][]string, 0, len(levels))
+	for _, level := range levels {
+		levelNames := make([]string, 0, len(level))
+		for _, module := range level {
+			levelNames = append(levelNames, module.ModuleName)
+		}
+		names = append(names, levelNames)
+	}
+	return names
+}
+
+func TestResolveDependencies(t *testing.T) {
+	t.Parallel()
+
+	istio := newModule("istio")
+	serverless := newModule("serverless", "istio")
+	eventing := newModule("eventing", "kyma-project.io/module/serverless", "istio")
+	keda := newModule("keda")
+
+	levels := common.ResolveDependencies(common.Modules{eventing, serverless, keda, istio})
+
+	assert.Equal(t, [][]string{{"keda", "istio"}, {"serverless"}, {"eventing"}}, levelNames(levels))
+	assert.Equal(t, []string{"serverless", "istio"}, eventing.DependencyNames())
+	for _, module := range []*common.Module{istio, serverless, eventing, keda} {
+		require.NoError(t, module.Template.Err)
+	}
+}
+
+func TestResolveDependencies_MissingDependency(t *testing.T) {
+	t.Parallel()
+
+	serverless := newModule("serverless", "istio")
+
+	common.ResolveDependencies(common.Modules{serverless})
+
+	require.ErrorIs(t, serverless.Template.Err, common.ErrDependencyMissing)
+	assert.Contains(t, serverless.Template.Err.Error(), "istio")
+}
+
+func TestResolveDependencies_Cycle(t *testing.T) {
+	t.Parallel()
+
+	first := newModule("first", "second")
+	second := newModule("second", "first")
+	independent := newModule("independent")
+
+	common.ResolveDependencies(common.Modules{first, second, independent})
+
+	require.ErrorIs(t, first.Template.Err, common.ErrDependencyCycle)
+	require.ErrorIs(t, second.Template.Err, common.ErrDependencyCycle)
+	assert.Contains(t, first.Template.Err.Error(), "first -> second -> first")
+	require.NoError(t, independent.Template.Err)
+}
+
+func TestModule_CheckDependenciesReady(t *testing.T) {
+	t.Parallel()
+
+	istio := newModule("istio")
+	serverless := newModule("serverless", "istio")
+	common.ResolveDependencies(common.Modules{istio, serverless})
+
+	require.ErrorIs(t, serverless.CheckDependenciesReady(), common.ErrDependencyNotReady)
+
+	istio.Object.(*v1beta2.Manifest).Status.State = shared.StateReady
+	require.NoError(t, serverless.CheckDependenciesReady())
+}
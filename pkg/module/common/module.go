@@ -20,6 +20,8 @@ type (
 		FQDN       string
 		Version    string
 		Template   *channel.ModuleTemplateTO
+		// DependsOn contains the modules of the same Kyma this module depends on, see ResolveDependencies.
+		DependsOn Modules
 		client.Object
 	}
 )
@@ -57,6 +59,16 @@ func (m *Module) ApplyLabelsAndAnnotations(
 		anns = make(map[string]string)
 	}
 	anns[v1beta2.FQDN] = m.FQDN
+	if len(m.DependsOn) > 0 {
+		anns[v1beta2.DependenciesAnnotation] = strings.Join(m.DependencyNames(), ",")
+	} else {
+		delete(anns, v1beta2.DependenciesAnnotation)
+	}
+	if targetNamespace := m.TargetNamespace(kyma); targetNamespace != "" {
+		anns[v1beta2.TargetNamespaceAnnotation] = targetNamespace
+	} else {
+		delete(anns, v1beta2.TargetNamespaceAnnotation)
+	}
 	m.SetAnnotations(anns)
 }
 
@@ -70,6 +82,42 @@ func (m *Module) IsRemoteModuleTemplate(kyma *v1beta2.Kyma) bool {
 	return false
 }
 
+// IsPaused returns true if the module is paused in the Kyma, see v1beta2.Module.Paused.
+func (m *Module) IsPaused(kyma *v1beta2.Kyma) bool {
+	for _, module := range kyma.Spec.Modules {
+		if module.Name == m.ModuleName {
+			return module.Paused
+		}
+	}
+
+	return false
+}
+
+// TargetNamespace returns the namespace the module is installed into in the Kyma, see v1beta2.Module.TargetNamespace.
+func (m *Module) TargetNamespace(kyma *v1beta2.Kyma) string {
+	for _, module := range kyma.Spec.Modules {
+		if module.Name == m.ModuleName {
+			return module.TargetNamespace
+		}
+	}
+
+	return ""
+}
+
+// Criticality returns the criticality of the module in the Kyma, which defaults to the one of its ModuleTemplate.
+func (m *Module) Criticality(kyma *v1beta2.Kyma) v1beta2.ModuleCriticality {
+	for _, module := range kyma.Spec.Modules {
+		if module.Name == m.ModuleName && module.Criticality != "" {
+			return module.Criticality
+		}
+	}
+	if m.Template != nil && m.Template.ModuleTemplate != nil {
+		return m.Template.GetCriticality()
+	}
+
+	return v1beta2.ModuleCritical
+}
+
 func (m *Module) ContainsExpectedOwnerReference(ownerName string) bool {
 	if m.GetOwnerReferences() == nil {
 		return false
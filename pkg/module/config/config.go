@@ -0,0 +1,118 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+var (
+	ErrInvalidModuleConfig = errors.New("invalid module config")
+	ErrConfigKeyNotFound   = errors.New("module config key not found")
+)
+
+// Validate verifies that exactly one source of overrides is set and that inline overrides can be decoded
+// for the patch type of the config.
+func Validate(config *v1beta2.ModuleConfig) error {
+	sources := 0
+	for _, set := range []bool{config.Inline != nil, config.ConfigMapRef != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("%w: exactly one of inline or configMapRef has to be set, found %d",
+			ErrInvalidModuleConfig, sources)
+	}
+	if config.Inline != nil {
+		if _, err := decodePatch(config.PatchType, config.Inline.Raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resolve returns the overrides of the config as JSON. ConfigMaps are read from the given namespace.
+func Resolve(ctx context.Context, clnt client.Reader, namespace string, config *v1beta2.ModuleConfig,
+) ([]byte, error) {
+	var data []byte
+	switch {
+	case config.Inline != nil:
+		return config.Inline.Raw, nil
+	case config.ConfigMapRef != nil:
+		configMap := &v1.ConfigMap{}
+		key := client.ObjectKey{Namespace: namespace, Name: config.ConfigMapRef.Name}
+		if err := clnt.Get(ctx, key, configMap); err != nil {
+			return nil, fmt.Errorf("failed to get module config from configmap %s: %w", key, err)
+		}
+		value, found := configMap.Data[config.ConfigMapRef.Key]
+		if !found {
+			return nil, fmt.Errorf("%w: %s in configmap %s", ErrConfigKeyNotFound, config.ConfigMapRef.Key, key)
+		}
+		data = []byte(value)
+	default:
+		return nil, fmt.Errorf("%w: no source of overrides is set", ErrInvalidModuleConfig)
+	}
+	overrides, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidModuleConfig, err)
+	}
+	return overrides, nil
+}
+
+// Apply patches the overrides into the given module CR. The identity of the module CR
+// (apiVersion, kind, name and namespace) cannot be overridden.
+func Apply(resource *unstructured.Unstructured, patchType v1beta2.ModuleConfigPatchType, overrides []byte) error {
+	original, err := resource.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal module CR: %w", err)
+	}
+	patch, err := decodePatch(patchType, overrides)
+	if err != nil {
+		return err
+	}
+	patched, err := patch(original)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidModuleConfig, err)
+	}
+
+	result := &unstructured.Unstructured{}
+	if err := result.UnmarshalJSON(patched); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidModuleConfig, err)
+	}
+	result.SetGroupVersionKind(resource.GroupVersionKind())
+	result.SetName(resource.GetName())
+	result.SetNamespace(resource.GetNamespace())
+	resource.Object = result.Object
+	return nil
+}
+
+func decodePatch(patchType v1beta2.ModuleConfigPatchType, overrides []byte) (func([]byte) ([]byte, error), error) {
+	switch patchType {
+	case v1beta2.ModuleConfigJSONPatch:
+		patch, err := jsonpatch.DecodePatch(overrides)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidModuleConfig, err)
+		}
+		return patch.Apply, nil
+	case v1beta2.ModuleConfigMergePatch, "":
+		var document map[string]any
+		if err := json.Unmarshal(overrides, &document); err != nil {
+			return nil, fmt.Errorf("%w: merge patch has to be an object: %w", ErrInvalidModuleConfig, err)
+		}
+		return func(original []byte) ([]byte, error) {
+			return jsonpatch.MergePatch(original, overrides)
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown patch type %s", ErrInvalidModuleConfig, patchType)
+	}
+}
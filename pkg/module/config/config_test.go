@@ -0,0 +1,100 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/config"
+)
+
+func newDefaultCR() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "operator.kyma-project.io/v1alpha1",
+		"kind":       "Sample",
+		"metadata":   map[string]any{"name": "sample", "namespace": "kyma-system"},
+		"spec":       map[string]any{"replicas": int64(1), "logLevel": "info"},
+	}}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		patchType v1beta2.ModuleConfigPatchType
+		overrides string
+		wantSpec  map[string]any
+		wantErr   error
+	}{
+		{
+			name:      "merge patch",
+			patchType: v1beta2.ModuleConfigMergePatch,
+			overrides: `{"spec":{"replicas":3,"logLevel":null}}`,
+			wantSpec:  map[string]any{"replicas": int64(3)},
+		},
+		{
+			name:      "json patch",
+			patchType: v1beta2.ModuleConfigJSONPatch,
+			overrides: `[{"op":"replace","path":"/spec/logLevel","value":"debug"}]`,
+			wantSpec:  map[string]any{"replicas": int64(1), "logLevel": "debug"},
+		},
+		{
+			name:      "merge patch that is no object",
+			patchType: v1beta2.ModuleConfigMergePatch,
+			overrides: `[{"op":"remove","path":"/spec"}]`,
+			wantErr:   config.ErrInvalidModuleConfig,
+		},
+		{
+			name:      "json patch to missing path",
+			patchType: v1beta2.ModuleConfigJSONPatch,
+			overrides: `[{"op":"replace","path":"/spec/missing/field","value":1}]`,
+			wantErr:   config.ErrInvalidModuleConfig,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			resource := newDefaultCR()
+			err := config.Apply(resource, testCase.patchType, []byte(testCase.overrides))
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantSpec, resource.Object["spec"])
+		})
+	}
+}
+
+func TestApply_KeepsIdentity(t *testing.T) {
+	t.Parallel()
+	resource := newDefaultCR()
+	overrides := `{"kind":"Other","metadata":{"name":"other","labels":{"team":"a"}}}`
+
+	require.NoError(t, config.Apply(resource, v1beta2.ModuleConfigMergePatch, []byte(overrides)))
+
+	assert.Equal(t, "Sample", resource.GetKind())
+	assert.Equal(t, "sample", resource.GetName())
+	assert.Equal(t, "kyma-system", resource.GetNamespace())
+	assert.Equal(t, map[string]string{"team": "a"}, resource.GetLabels())
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	inline := &runtime.RawExtension{Raw: []byte(`{"spec":{"replicas":3}}`)}
+	ref := &v1beta2.ModuleConfigKeyRef{Name: "overrides", Key: "sample"}
+
+	require.NoError(t, config.Validate(&v1beta2.ModuleConfig{Inline: inline}))
+	require.NoError(t, config.Validate(&v1beta2.ModuleConfig{ConfigMapRef: ref}))
+	require.ErrorIs(t, config.Validate(&v1beta2.ModuleConfig{}), config.ErrInvalidModuleConfig)
+	require.ErrorIs(t, config.Validate(&v1beta2.ModuleConfig{Inline: inline, ConfigMapRef: ref}),
+		config.ErrInvalidModuleConfig)
+	require.ErrorIs(t, config.Validate(&v1beta2.ModuleConfig{
+		PatchType: v1beta2.ModuleConfigJSONPatch, Inline: inline,
+	}), config.ErrInvalidModuleConfig)
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+)
+
+var errTypeAssertKyma = errors.New("object is not a kyma")
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-operator-kyma-project-io-v1beta2-kyma,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.kyma-project.io,resources=kymas,verbs=create;update,versions=v1beta2,name=v1beta2.vkyma.kb.io,admissionReviewVersions=v1
+
+// Validator validates the maintenance windows and the modules of a Kyma. A window has to stay open for a positive
+// duration of at most a week. A module must not set both a channel and a version,
+// as a version pin is resolved regardless of the channel. The config overrides are applied to
+// the default module CR of the resolved ModuleTemplate and validated against the schema of the module CRD.
+// As module CRDs are usually only installed in the remote cluster, the schema validation is skipped
+// with a warning if the CRD cannot be found.
+type Validator struct {
+	client.Reader
+	lookupOptions channel.LookupOptions
+}
+
+var _ webhook.CustomValidator = &Validator{}
+
+func NewValidator(clnt client.Reader, lookupOptions channel.LookupOptions) *Validator {
+	return &Validator{Reader: clnt, lookupOptions: lookupOptions}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1beta2.Kyma{}).
+		WithValidator(v).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed to setup module config webhook with manager: %w", err)
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kyma, ok := obj.(*v1beta2.Kyma)
+	if !ok {
+		return nil, errTypeAssertKyma
+	}
+	return v.validate(ctx, nil, kyma)
+}
+
+// ValidateUpdate implements webhook.CustomValidator. It only validates the configs of modules that changed,
+// as every update of the Kyma, e.g. by its controller, is validated.
+func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldKyma, ok := oldObj.(*v1beta2.Kyma)
+	if !ok {
+		return nil, errTypeAssertKyma
+	}
+	kyma, ok := newObj.(*v1beta2.Kyma)
+	if !ok {
+		return nil, errTypeAssertKyma
+	}
+	return v.validate(ctx, oldKyma, kyma)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *Validator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *Validator) validate(ctx context.Context, oldKyma, kyma *v1beta2.Kyma) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	if oldKyma == nil || !equality.Semantic.DeepEqual(oldKyma.Spec.MaintenanceWindows, kyma.Spec.MaintenanceWindows) {
+		errs = v1beta2.ValidateMaintenanceWindows(kyma.Spec.MaintenanceWindows,
+			field.NewPath("spec").Child("maintenanceWindows"))
+	}
+	for i, module := range kyma.Spec.Modules {
+		if module.Channel != "" && module.Version != "" {
+			errs = append(errs, field.Forbidden(field.NewPath("spec").Child("modules").Index(i).Child("version"),
+				fmt.Sprintf("module %s cannot set both a channel and a version", module.Name)))
+		}
+		if module.Config == nil || configUnchanged(oldKyma, kyma, module) {
+			continue
+		}
+		path := field.NewPath("spec").Child("modules").Index(i).Child("config")
+		if err := Validate(module.Config); err != nil {
+			errs = append(errs, field.Invalid(path, module.Name, err.Error()))
+			continue
+		}
+		warning, fieldErrs := v.validateModule(ctx, kyma, module, path)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		errs = append(errs, fieldErrs...)
+	}
+
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(
+			schema.GroupKind{Group: v1beta2.GroupVersion.Group, Kind: string(v1beta2.KymaKind)},
+			kyma.GetName(), errs)
+	}
+	return warnings, nil
+}
+
+// configUnchanged returns true if the old Kyma had the module with the same config that was resolved
+// from the same ModuleTemplate, so the config was already validated.
+func configUnchanged(oldKyma, kyma *v1beta2.Kyma, module v1beta2.Module) bool {
+	if oldKyma == nil || oldKyma.Spec.Channel != kyma.Spec.Channel {
+		return false
+	}
+	for _, oldModule := range oldKyma.Spec.Modules {
+		if oldModule.Name != module.Name {
+			continue
+		}
+		return oldModule.Channel == module.Channel &&
+			oldModule.Version == module.Version &&
+			oldModule.RemoteModuleTemplateRef == module.RemoteModuleTemplateRef &&
+			oldModule.CustomResourcePolicy == module.CustomResourcePolicy &&
+			equality.Semantic.DeepEqual(oldModule.Config, module.Config)
+	}
+	return false
+}
+
+func (v *Validator) validateModule(ctx context.Context, kyma *v1beta2.Kyma, module v1beta2.Module,
+	path *field.Path,
+) (string, field.ErrorList) {
+	if module.CustomResourcePolicy == v1beta2.CustomResourcePolicyIgnore {
+		return fmt.Sprintf("config of module %s has no effect with customResourcePolicy %s",
+			module.Name, module.CustomResourcePolicy), nil
+	}
+	if module.RemoteModuleTemplateRef != "" {
+		return fmt.Sprintf("config of module %s is not validated as it uses a remote module template",
+			module.Name), nil
+	}
+
+	template := channel.NewTemplateLookup(v, module, kyma.Spec.Channel).WithOptions(v.lookupOptions).WithContext(ctx)
+	if template.Err != nil {
+		return fmt.Sprintf("config of module %s is not validated: %s", module.Name, template.Err), nil
+	}
+	if template.Spec.Data == nil {
+		return fmt.Sprintf("config of module %s has no effect as the module template has no default data",
+			module.Name), nil
+	}
+
+	overrides, err := Resolve(ctx, v, kyma.GetNamespace(), module.Config)
+	if err != nil {
+		return fmt.Sprintf("config of module %s is not validated: %s", module.Name, err), nil
+	}
+	resource := template.Spec.Data.DeepCopy()
+	if err := Apply(resource, module.Config.PatchType, overrides); err != nil {
+		return "", field.ErrorList{field.Invalid(path, module.Name, err.Error())}
+	}
+
+	validator, err := v.schemaValidator(ctx, resource.GroupVersionKind())
+	if err != nil {
+		return fmt.Sprintf("config of module %s is not validated: %s", module.Name, err), nil
+	}
+	if validator == nil {
+		return fmt.Sprintf("config of module %s is not validated as the CRD of %s is not installed",
+			module.Name, resource.GroupVersionKind()), nil
+	}
+	result := validator.Validate(resource.UnstructuredContent())
+	if result.IsValid() {
+		return "", nil
+	}
+	errs := make(field.ErrorList, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		errs = append(errs, field.Invalid(path, module.Name, err.Error()))
+	}
+	return "", errs
+}
+
+// schemaValidator returns a validator for the schema of the given kind or nil if no CRD is installed for it.
+func (v *Validator) schemaValidator(ctx context.Context, gvk schema.GroupVersionKind,
+) (*validate.SchemaValidator, error) {
+	crds := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := v.List(ctx, crds); err != nil {
+		return nil, fmt.Errorf("failed to list custom resource definitions: %w", err)
+	}
+	for _, crd := range crds.Items {
+		if crd.Spec.Group != gvk.Group || crd.Spec.Names.Kind != gvk.Kind {
+			continue
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Name != gvk.Version || version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			// the JSON representation of a CRD schema is a valid OpenAPI schema.
+			raw, err := json.Marshal(version.Schema.OpenAPIV3Schema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal schema of %s: %w", crd.GetName(), err)
+			}
+			openAPISchema := &spec.Schema{}
+			if err := json.Unmarshal(raw, openAPISchema); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal schema of %s: %w", crd.GetName(), err)
+			}
+			return validate.NewSchemaValidator(openAPISchema, nil, "", strfmt.Default), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,266 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	compdescv2 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/versions/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/config"
+	"github.com/kyma-project/lifecycle-manager/pkg/testutils/builder"
+)
+
+const sampleModuleName = "sample"
+
+// countingReader counts the reads of the validator, which are all expensive in a webhook.
+type countingReader struct {
+	client.Reader
+	reads int
+}
+
+func (r *countingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object,
+	opts ...client.GetOption,
+) error {
+	r.reads++
+	return r.Reader.Get(ctx, key, obj, opts...) //nolint:wrapcheck
+}
+
+func (r *countingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.reads++
+	return r.Reader.List(ctx, list, opts...) //nolint:wrapcheck
+}
+
+func newSampleCRD() *apiextensionsv1.CustomResourceDefinition {
+	maxReplicas := float64(5)
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "samples.operator.kyma-project.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "operator.kyma-project.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Sample", Plural: "samples"},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name: "v1alpha1", Served: true, Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"replicas": {Type: "integer", Maximum: &maxReplicas},
+									"logLevel": {Type: "string"},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func newValidator(t *testing.T, objs ...client.Object) (*config.Validator, *countingReader) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta2.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	template := builder.NewModuleTemplateBuilder().
+		WithModuleName(sampleModuleName).
+		WithChannel(v1beta2.DefaultChannel).
+		WithModuleCR(newDefaultCR()).
+		WithOCM(compdescv2.SchemaVersion).
+		Build()
+	reader := &countingReader{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, template)...).Build(),
+	}
+	return config.NewValidator(reader, channel.LookupOptions{}), reader
+}
+
+func newKymaWithConfig(overrides string) *v1beta2.Kyma {
+	return &v1beta2.Kyma{
+		ObjectMeta: metav1.ObjectMeta{Name: "kyma", Namespace: "kcp-system"},
+		Spec: v1beta2.KymaSpec{
+			Channel: v1beta2.DefaultChannel,
+			Modules: []v1beta2.Module{{
+				Name:   sampleModuleName,
+				Config: &v1beta2.ModuleConfig{Inline: &runtime.RawExtension{Raw: []byte(overrides)}},
+			}},
+		},
+	}
+}
+
+func TestValidator_ValidateCreate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		overrides    string
+		withoutCRD   bool
+		wantInvalid  bool
+		wantWarnings int
+	}{
+		{name: "valid config", overrides: `{"spec":{"replicas":3}}`},
+		{name: "config violating the schema", overrides: `{"spec":{"replicas":10}}`, wantInvalid: true},
+		{
+			name: "config without installed CRD", overrides: `{"spec":{"replicas":10}}`,
+			withoutCRD: true, wantWarnings: 1,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var objs []client.Object
+			if !testCase.withoutCRD {
+				objs = append(objs, newSampleCRD())
+			}
+			validator, _ := newValidator(t, objs...)
+
+			warnings, err := validator.ValidateCreate(context.Background(), newKymaWithConfig(testCase.overrides))
+
+			assert.Len(t, warnings, testCase.wantWarnings)
+			if testCase.wantInvalid {
+				require.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidator_RejectsChannelWithVersion(t *testing.T) {
+	t.Parallel()
+	validator, _ := newValidator(t)
+	kyma := &v1beta2.Kyma{
+		ObjectMeta: metav1.ObjectMeta{Name: "kyma", Namespace: "kcp-system"},
+		Spec: v1beta2.KymaSpec{
+			Channel: v1beta2.DefaultChannel,
+			Modules: []v1beta2.Module{
+				{Name: "pinned", Version: "1.4.2"},
+				{Name: sampleModuleName, Channel: "fast", Version: "~1.4"},
+			},
+		},
+	}
+
+	_, err := validator.ValidateCreate(context.Background(), kyma)
+
+	require.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+	var statusErr *apierrors.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Len(t, statusErr.ErrStatus.Details.Causes, 1)
+	assert.Equal(t, "spec.modules[1].version", statusErr.ErrStatus.Details.Causes[0].Field)
+
+	kyma.Spec.Modules[1].Channel = ""
+	_, err = validator.ValidateCreate(context.Background(), kyma)
+	require.NoError(t, err)
+}
+
+func TestValidator_RejectsInvalidMaintenanceWindowDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		duration    time.Duration
+		wantInvalid bool
+	}{
+		{name: "valid duration", duration: 4 * time.Hour},
+		{name: "a week", duration: v1beta2.MaxMaintenanceWindowDuration},
+		{name: "zero duration", duration: 0, wantInvalid: true},
+		{name: "negative duration", duration: -time.Hour, wantInvalid: true},
+		{name: "longer than a week", duration: v1beta2.MaxMaintenanceWindowDuration + time.Minute, wantInvalid: true},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			validator, _ := newValidator(t)
+			kyma := &v1beta2.Kyma{
+				ObjectMeta: metav1.ObjectMeta{Name: "kyma", Namespace: "kcp-system"},
+				Spec: v1beta2.KymaSpec{
+					Channel: v1beta2.DefaultChannel,
+					MaintenanceWindows: []v1beta2.MaintenanceWindow{
+						{Start: "22:00", Duration: metav1.Duration{Duration: testCase.duration}},
+					},
+				},
+			}
+
+			_, err := validator.ValidateCreate(context.Background(), kyma)
+
+			if !testCase.wantInvalid {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+			var statusErr *apierrors.StatusError
+			require.ErrorAs(t, err, &statusErr)
+			require.Len(t, statusErr.ErrStatus.Details.Causes, 1)
+			assert.Equal(t, "spec.maintenanceWindows[0].duration", statusErr.ErrStatus.Details.Causes[0].Field)
+		})
+	}
+}
+
+func TestValidator_ValidateUpdate(t *testing.T) {
+	t.Parallel()
+
+	invalid := `{"spec":{"replicas":10}}`
+	tests := []struct {
+		name        string
+		update      func(kyma *v1beta2.Kyma)
+		wantInvalid bool
+		wantNoReads bool
+	}{
+		{
+			name:        "unchanged config",
+			update:      func(kyma *v1beta2.Kyma) { kyma.Labels = map[string]string{"a": "b"} },
+			wantNoReads: true,
+		},
+		{
+			name: "changed config",
+			update: func(kyma *v1beta2.Kyma) {
+				kyma.Spec.Modules[0].Config.Inline.Raw = []byte(`{"spec":{"replicas":11}}`)
+			},
+			wantInvalid: true,
+		},
+		{
+			name:        "changed channel",
+			update:      func(kyma *v1beta2.Kyma) { kyma.Spec.Modules[0].Channel = v1beta2.DefaultChannel },
+			wantInvalid: true,
+		},
+		{
+			name:   "added module without template",
+			update: func(kyma *v1beta2.Kyma) { kyma.Spec.Modules[0].Name = "renamed" },
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			validator, reader := newValidator(t, newSampleCRD())
+			oldKyma := newKymaWithConfig(invalid)
+			kyma := oldKyma.DeepCopy()
+			testCase.update(kyma)
+
+			_, err := validator.ValidateUpdate(context.Background(), oldKyma, kyma)
+
+			if testCase.wantInvalid {
+				require.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			if testCase.wantNoReads {
+				assert.Zero(t, reader.reads, "an unchanged config is not validated again")
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/kyma-project/lifecycle-manager/pkg/channel"
 	"github.com/kyma-project/lifecycle-manager/pkg/img"
 	"github.com/kyma-project/lifecycle-manager/pkg/module/common"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/config"
 	"github.com/kyma-project/lifecycle-manager/pkg/signature"
 )
 
@@ -78,7 +79,7 @@ func (p *Parser) GenerateModulesFromTemplates(ctx context.Context,
 		name := common.CreateModuleName(fqdn, kyma.Name, module.Name)
 		overwriteNameAndNamespace(template, name, p.remoteSyncNamespace)
 		var obj client.Object
-		if obj, err = p.newManifestFromTemplate(ctx, module,
+		if obj, err = p.newManifestFromTemplate(ctx, kyma, module,
 			template.ModuleTemplate); err != nil {
 			template.Err = err
 			modules = append(modules, &common.Module{
@@ -119,6 +120,7 @@ func overwriteNameAndNamespace(template *channel.ModuleTemplateTO, name, namespa
 
 func (p *Parser) newManifestFromTemplate(
 	ctx context.Context,
+	kyma *v1beta2.Kyma,
 	module v1beta2.Module,
 	template *v1beta2.ModuleTemplate,
 ) (*v1beta2.Manifest, error) {
@@ -136,6 +138,21 @@ func (p *Parser) newManifestFromTemplate(
 		}
 	}
 
+	// the module CR is installed with the other resources of the module.
+	if manifest.Spec.Resource != nil && module.TargetNamespace != "" {
+		manifest.Spec.Resource.SetNamespace(module.TargetNamespace)
+	}
+
+	if manifest.Spec.Resource != nil && module.Config != nil {
+		overrides, err := config.Resolve(ctx, p.Client, kyma.GetNamespace(), module.Config)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve module config: %w", err)
+		}
+		if err := config.Apply(manifest.Spec.Resource, module.Config.PatchType, overrides); err != nil {
+			return nil, fmt.Errorf("could not apply module config: %w", err)
+		}
+	}
+
 	clusterClient := p.Client
 	if module.RemoteModuleTemplateRef != "" {
 		syncContext, err := remote.SyncContextFromContext(ctx)
@@ -0,0 +1,96 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+var (
+	ErrUnauthenticated = errors.New("request is not authenticated")
+	ErrForbidden       = errors.New("request is forbidden")
+)
+
+const kymaResource = "kymas"
+
+// Authorizer authorizes a request to plan the Kyma with the given key.
+type Authorizer interface {
+	Authorize(req *http.Request, key types.NamespacedName) error
+}
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// AccessReviewAuthorizer authorizes requests by their bearer token like the API server. The token is reviewed
+// by a TokenReview, and a SubjectAccessReview checks that its user may get the Kyma, or update it
+// to plan another spec with a POST.
+type AccessReviewAuthorizer struct {
+	client.Client
+}
+
+func (a *AccessReviewAuthorizer) Authorize(req *http.Request, key types.NamespacedName) error {
+	token, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return fmt.Errorf("%w: bearer token is missing", ErrUnauthenticated)
+	}
+	user, err := a.authenticate(req.Context(), token)
+	if err != nil {
+		return err
+	}
+
+	verb := "get"
+	if req.Method == http.MethodPost {
+		verb = "update"
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for name, value := range user.Extra {
+		extra[name] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+			Verb:      verb,
+			Group:     v1beta2.GroupVersion.Group,
+			Resource:  kymaResource,
+		},
+	}}
+	if err := a.Create(req.Context(), review); err != nil {
+		return fmt.Errorf("failed to review access of %s: %w", user.Username, err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("%w: %s cannot %s kyma %s", ErrForbidden, user.Username, verb, key)
+	}
+	return nil
+}
+
+func (a *AccessReviewAuthorizer) authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := a.Create(ctx, review); err != nil {
+		return authenticationv1.UserInfo{}, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, fmt.Errorf("%w: %s", ErrUnauthenticated, review.Status.Error)
+	}
+	return review.Status.User, nil
+}
+
+// denyAll is used if a Handler has no Authorizer, so that the endpoint is never exposed by accident.
+type denyAll struct{}
+
+func (denyAll) Authorize(*http.Request, types.NamespacedName) error {
+	return fmt.Errorf("%w: no authorizer configured", ErrForbidden)
+}
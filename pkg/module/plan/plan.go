@@ -0,0 +1,191 @@
+package plan
+
+import (
+	"errors"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/common"
+)
+
+// Action describes what a reconciliation of the planned KymaSpec would do with a module.
+type Action string
+
+const (
+	// ActionInstall creates the Manifest of a module that is not installed yet.
+	ActionInstall Action = "Install"
+	// ActionUpdate updates the Manifest of an installed module to another ModuleTemplate, channel or version.
+	ActionUpdate Action = "Update"
+	// ActionRollback downgrades an installed module as permitted by its ModuleTemplate.
+	ActionRollback Action = "Rollback"
+	// ActionDelete deletes the Manifest of an installed module.
+	ActionDelete Action = "Delete"
+	// ActionNone keeps an installed module as it is.
+	ActionNone Action = "None"
+	// ActionBlocked means the change is rejected, e.g. by a channel or version skew.
+	ActionBlocked Action = "Blocked"
+	// ActionDeferred means the change is postponed, e.g. to the next maintenance window.
+	ActionDeferred Action = "Deferred"
+	// ActionPaused means the change is not applied as the module is paused.
+	ActionPaused Action = "Paused"
+	// ActionError means no ModuleTemplate could be resolved for the module.
+	ActionError Action = "Error"
+)
+
+// Plan is the structured diff between the modules installed for a Kyma and the modules
+// that a reconciliation of a (proposed) KymaSpec would install.
+type Plan struct {
+	Kyma    string       `json:"kyma"`
+	Modules []ModulePlan `json:"modules"`
+}
+
+type ModulePlan struct {
+	Name   string `json:"name"`
+	Action Action `json:"action"`
+	// Manifest is the Manifest that is created, updated or deleted for the module.
+	Manifest string `json:"manifest,omitempty"`
+	// Current is the state of the module as tracked in the Kyma status, unset for new modules.
+	Current *ModuleState `json:"current,omitempty"`
+	// Desired is the state of the module after the reconciliation, unset for deleted modules.
+	Desired *ModuleState `json:"desired,omitempty"`
+	// Message explains why a change is blocked, deferred or failed.
+	Message string `json:"message,omitempty"`
+}
+
+type ModuleState struct {
+	Template           string                   `json:"template,omitempty"`
+	TemplateGeneration int64                    `json:"templateGeneration,omitempty"`
+	Channel            string                   `json:"channel,omitempty"`
+	Version            string                   `json:"version,omitempty"`
+	ResolvedBy         v1beta2.ModuleResolution `json:"resolvedBy,omitempty"`
+}
+
+// Blocked returns true if a change of the plan is rejected and would not be applied.
+func (p *Plan) Blocked() bool {
+	for _, module := range p.Modules {
+		if module.Action == ActionBlocked || module.Action == ActionError {
+			return true
+		}
+	}
+	return false
+}
+
+// New creates the plan for the modules generated from the spec of the Kyma.
+// The modules are compared with the module status of the Kyma, which reflects what is installed.
+func New(kyma *v1beta2.Kyma, modules common.Modules) *Plan {
+	common.ResolveDependencies(modules)
+	moduleStatusMap := kyma.GetModuleStatusMap()
+
+	plan := &Plan{
+		Kyma:    trackingKey(kyma.GetNamespace(), kyma.GetName()),
+		Modules: make([]ModulePlan, 0, len(modules)),
+	}
+	for _, module := range modules {
+		modulePlan := newModulePlan(module, moduleStatusMap[module.ModuleName])
+		if module.IsPaused(kyma) && modulePlan.Action != ActionNone {
+			modulePlan.Action = ActionPaused
+			modulePlan.Message = "module is paused"
+		}
+		plan.Modules = append(plan.Modules, modulePlan)
+	}
+	for _, moduleStatus := range kyma.GetNoLongerExistingModuleStatus() {
+		if moduleStatus.Manifest == nil {
+			continue
+		}
+		plan.Modules = append(plan.Modules, ModulePlan{
+			Name:     moduleStatus.Name,
+			Action:   ActionDelete,
+			Manifest: moduleStatus.Manifest.GetName(),
+			Current:  currentState(moduleStatus),
+			Message:  "module is no longer part of the spec",
+		})
+	}
+	sort.SliceStable(plan.Modules, func(i, j int) bool {
+		return plan.Modules[i].Name < plan.Modules[j].Name
+	})
+	return plan
+}
+
+func newModulePlan(module *common.Module, moduleStatus *v1beta2.ModuleStatus) ModulePlan {
+	modulePlan := ModulePlan{Name: module.ModuleName}
+	installed := moduleStatus != nil && moduleStatus.Manifest != nil
+	if installed {
+		modulePlan.Current = currentState(moduleStatus)
+		modulePlan.Manifest = moduleStatus.Manifest.GetName()
+	}
+	if module.Object != nil {
+		modulePlan.Manifest = module.GetName()
+	}
+	if module.Template != nil && module.Template.ModuleTemplate != nil {
+		modulePlan.Desired = &ModuleState{
+			Template:           trackingKey(module.Template.GetNamespace(), module.Template.GetName()),
+			TemplateGeneration: module.Template.GetGeneration(),
+			Channel:            module.Template.Spec.Channel,
+			Version:            module.Version,
+			ResolvedBy:         module.Template.ResolvedBy,
+		}
+	}
+
+	if err := module.Template.Err; err != nil {
+		modulePlan.Message = err.Error()
+		switch {
+		case errors.Is(err, channel.ErrTemplateNotAllowed):
+			modulePlan.Action = ActionDelete
+			modulePlan.Desired = nil
+			if !installed {
+				modulePlan.Action = ActionNone
+			}
+		case errors.Is(err, channel.ErrTemplateUpdateNotAllowed),
+			errors.Is(err, common.ErrDependencyMissing),
+			errors.Is(err, common.ErrDependencyCycle):
+			modulePlan.Action = ActionBlocked
+		case errors.Is(err, channel.ErrTemplateUpdateDeferred):
+			modulePlan.Action = ActionDeferred
+		case module.Template.Orphan != nil && module.Template.Orphan.Uninstall && installed:
+			modulePlan.Action = ActionDelete
+		case module.Template.Orphan != nil && module.Template.Orphan.Action != v1beta2.OrphanActionFail:
+			modulePlan.Action = ActionNone
+		default:
+			modulePlan.Action = ActionError
+		}
+		return modulePlan
+	}
+
+	switch {
+	case !installed:
+		modulePlan.Action = ActionInstall
+	case module.Template.RollbackFrom != "":
+		modulePlan.Action = ActionRollback
+	case !modulePlan.Current.sameAs(modulePlan.Desired):
+		modulePlan.Action = ActionUpdate
+	default:
+		modulePlan.Action = ActionNone
+	}
+	return modulePlan
+}
+
+func currentState(moduleStatus *v1beta2.ModuleStatus) *ModuleState {
+	state := &ModuleState{
+		Channel:    moduleStatus.Channel,
+		Version:    moduleStatus.Version,
+		ResolvedBy: moduleStatus.ResolvedBy,
+	}
+	if moduleStatus.Template != nil {
+		state.Template = trackingKey(moduleStatus.Template.GetNamespace(), moduleStatus.Template.GetName())
+		state.TemplateGeneration = moduleStatus.Template.GetGeneration()
+	}
+	return state
+}
+
+// sameAs ignores ResolvedBy as it is not tracked for modules installed before it was introduced.
+func (s *ModuleState) sameAs(other *ModuleState) bool {
+	return s.Template == other.Template && s.TemplateGeneration == other.TemplateGeneration &&
+		s.Channel == other.Channel && s.Version == other.Version
+}
+
+func trackingKey(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
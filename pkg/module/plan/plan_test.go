@@ -0,0 +1,118 @@
+package plan_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/common"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/plan"
+	"github.com/kyma-project/lifecycle-manager/pkg/testutils/builder"
+)
+
+const namespace = "kcp-system"
+
+//nolint:funlen
+func TestNew(t *testing.T) {
+	t.Parallel()
+	addedTemplate := builder.NewModuleTemplateBuilder().
+		WithName("added-regular").WithNamespace(namespace).WithChannel("regular").WithGeneration(1).Build()
+	unchangedTemplate := builder.NewModuleTemplateBuilder().
+		WithName("unchanged-regular").WithNamespace(namespace).WithChannel("regular").WithGeneration(1).Build()
+	switchedFromTemplate := builder.NewModuleTemplateBuilder().
+		WithName("switched-regular").WithNamespace(namespace).WithChannel("regular").WithGeneration(1).Build()
+	switchedToTemplate := builder.NewModuleTemplateBuilder().
+		WithName("switched-fast").WithNamespace(namespace).WithChannel("fast").WithGeneration(1).Build()
+	skewedFromTemplate := builder.NewModuleTemplateBuilder().
+		WithName("skewed-fast").WithNamespace(namespace).WithChannel("fast").WithGeneration(1).Build()
+	skewedToTemplate := builder.NewModuleTemplateBuilder().
+		WithName("skewed-regular").WithNamespace(namespace).WithChannel("regular").WithGeneration(1).Build()
+	deferredFromTemplate := builder.NewModuleTemplateBuilder().
+		WithName("deferred-regular").WithNamespace(namespace).WithChannel("regular").WithGeneration(1).Build()
+	deferredToTemplate := builder.NewModuleTemplateBuilder().
+		WithName("deferred-regular").WithNamespace(namespace).WithChannel("regular").WithGeneration(2).Build()
+	removedTemplate := builder.NewModuleTemplateBuilder().
+		WithName("removed-regular").WithNamespace(namespace).WithChannel("regular").WithGeneration(1).Build()
+
+	kyma := builder.NewKymaBuilder().WithName("kyma").WithNamespace(namespace).
+		WithModule(v1beta2.Module{Name: "added"}).
+		WithModule(v1beta2.Module{Name: "unchanged"}).
+		WithModule(v1beta2.Module{Name: "switched"}).
+		WithModule(v1beta2.Module{Name: "skewed"}).
+		WithModule(v1beta2.Module{Name: "deferred"}).
+		WithModuleStatus(builder.NewModuleStatusBuilder().WithName("unchanged").WithChannel("regular").
+			WithVersion("1.0.0").WithManifest("kyma-unchanged").WithTemplate(unchangedTemplate).Build()).
+		WithModuleStatus(builder.NewModuleStatusBuilder().WithName("switched").WithChannel("regular").
+			WithVersion("1.0.0").WithManifest("kyma-switched").WithTemplate(switchedFromTemplate).Build()).
+		WithModuleStatus(builder.NewModuleStatusBuilder().WithName("skewed").WithChannel("fast").
+			WithVersion("2.0.0").WithManifest("kyma-skewed").WithTemplate(skewedFromTemplate).Build()).
+		WithModuleStatus(builder.NewModuleStatusBuilder().WithName("deferred").WithChannel("regular").
+			WithVersion("1.0.0").WithManifest("kyma-deferred").WithTemplate(deferredFromTemplate).Build()).
+		WithModuleStatus(builder.NewModuleStatusBuilder().WithName("removed").WithChannel("regular").
+			WithVersion("1.0.0").WithManifest("kyma-removed").WithTemplate(removedTemplate).Build()).
+		Build()
+	modules := common.Modules{
+		{
+			ModuleName: "added", Version: "1.0.0",
+			Template: &channel.ModuleTemplateTO{ModuleTemplate: addedTemplate},
+			Object:   &v1beta2.Manifest{ObjectMeta: metav1.ObjectMeta{Name: "kyma-added", Namespace: namespace}},
+		},
+		{
+			ModuleName: "unchanged", Version: "1.0.0",
+			Template: &channel.ModuleTemplateTO{ModuleTemplate: unchangedTemplate},
+			Object:   &v1beta2.Manifest{ObjectMeta: metav1.ObjectMeta{Name: "kyma-unchanged", Namespace: namespace}},
+		},
+		{
+			ModuleName: "switched", Version: "1.1.0",
+			Template: &channel.ModuleTemplateTO{ModuleTemplate: switchedToTemplate},
+			Object:   &v1beta2.Manifest{ObjectMeta: metav1.ObjectMeta{Name: "kyma-switched", Namespace: namespace}},
+		},
+		{
+			ModuleName: "skewed", Version: "1.0.0",
+			Template: &channel.ModuleTemplateTO{
+				ModuleTemplate: skewedToTemplate,
+				Err:            fmt.Errorf("%w: version would be decremented", channel.ErrTemplateUpdateNotAllowed),
+			},
+			Object: &v1beta2.Manifest{ObjectMeta: metav1.ObjectMeta{Name: "kyma-skewed", Namespace: namespace}},
+		},
+		{
+			ModuleName: "deferred", Version: "1.1.0",
+			Template: &channel.ModuleTemplateTO{
+				ModuleTemplate: deferredToTemplate,
+				Err:            fmt.Errorf("%w: outside of maintenance window", channel.ErrTemplateUpdateDeferred),
+			},
+			Object: &v1beta2.Manifest{ObjectMeta: metav1.ObjectMeta{Name: "kyma-deferred", Namespace: namespace}},
+		},
+	}
+
+	result := plan.New(kyma, modules)
+
+	assert.Equal(t, namespace+"/kyma", result.Kyma)
+	actions := make(map[string]plan.Action, len(result.Modules))
+	for _, module := range result.Modules {
+		actions[module.Name] = module.Action
+	}
+	assert.Equal(t, map[string]plan.Action{
+		"added":     plan.ActionInstall,
+		"unchanged": plan.ActionNone,
+		"switched":  plan.ActionUpdate,
+		"skewed":    plan.ActionBlocked,
+		"deferred":  plan.ActionDeferred,
+		"removed":   plan.ActionDelete,
+	}, actions)
+	assert.True(t, result.Blocked())
+
+	switched := result.Modules[4]
+	assert.Equal(t, "switched", switched.Name)
+	assert.Equal(t, "kyma-switched", switched.Manifest)
+	assert.Equal(t, &plan.ModuleState{
+		Template: namespace + "/switched-regular", TemplateGeneration: 1, Channel: "regular", Version: "1.0.0",
+	}, switched.Current)
+	assert.Equal(t, &plan.ModuleState{
+		Template: namespace + "/switched-fast", TemplateGeneration: 1, Channel: "fast", Version: "1.1.0",
+	}, switched.Desired)
+}
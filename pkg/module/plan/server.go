@@ -0,0 +1,140 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlLog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/common"
+	"github.com/kyma-project/lifecycle-manager/pkg/util"
+)
+
+const (
+	Path = "/plan"
+
+	serverTimeout = 30 * time.Second
+	maxBodyBytes  = 1 << 20
+)
+
+// ModuleGenerator generates the modules of a Kyma the same way as a reconciliation,
+// but without modifying any resource.
+type ModuleGenerator interface {
+	DryRunModulesFromTemplate(ctx context.Context, kyma *v1beta2.Kyma) (common.Modules, error)
+}
+
+// Handler serves plans for Kymas under Path?namespace=<namespace>&name=<name>.
+// A GET plans the current spec of the Kyma, a POST plans the KymaSpec in the request body instead.
+// Every request must be admitted by the Authorizer, without one, all requests are forbidden.
+type Handler struct {
+	client.Reader
+	Generator  ModuleGenerator
+	Authorizer Authorizer
+}
+
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodPost {
+		http.Error(writer, fmt.Sprintf("method %s is not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	key := types.NamespacedName{Namespace: req.URL.Query().Get("namespace"), Name: req.URL.Query().Get("name")}
+	if key.Namespace == "" || key.Name == "" {
+		http.Error(writer, "query parameters namespace and name are required", http.StatusBadRequest)
+		return
+	}
+	if err := h.authorizer().Authorize(req, key); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrUnauthenticated):
+			status = http.StatusUnauthorized
+		case errors.Is(err, ErrForbidden):
+			status = http.StatusForbidden
+		}
+		http.Error(writer, err.Error(), status)
+		return
+	}
+
+	kyma := &v1beta2.Kyma{}
+	if err := h.Get(req.Context(), key, kyma); err != nil {
+		status := http.StatusInternalServerError
+		if util.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		http.Error(writer, err.Error(), status)
+		return
+	}
+	if req.Method == http.MethodPost {
+		spec := v1beta2.KymaSpec{}
+		if err := json.NewDecoder(http.MaxBytesReader(writer, req.Body, maxBodyBytes)).Decode(&spec); err != nil {
+			http.Error(writer, fmt.Sprintf("invalid kyma spec: %s", err), http.StatusBadRequest)
+			return
+		}
+		kyma.Spec = spec
+	}
+
+	modules, err := h.Generator.DryRunModulesFromTemplate(req.Context(), kyma)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(New(kyma, modules)); err != nil {
+		ctrlLog.FromContext(req.Context()).Error(err, "failed to write plan", "kyma", key)
+	}
+}
+
+func (h *Handler) authorizer() Authorizer {
+	if h.Authorizer == nil {
+		return denyAll{}
+	}
+	return h.Authorizer
+}
+
+// Server serves the Handler on every replica, as planning does not depend on leader election.
+type Server struct {
+	Addr    string
+	Handler http.Handler
+}
+
+var (
+	_ manager.Runnable               = &Server{}
+	_ manager.LeaderElectionRunnable = &Server{}
+)
+
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(Path, s.Handler)
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           mux,
+		ReadTimeout:       serverTimeout,
+		ReadHeaderTimeout: serverTimeout,
+		WriteTimeout:      serverTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serverTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			ctrlLog.Log.Error(err, "failed to shut down plan server")
+		}
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("plan server failed: %w", err)
+	}
+	return nil
+}
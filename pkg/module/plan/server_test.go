@@ -0,0 +1,200 @@
+package plan_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/common"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/plan"
+)
+
+const validToken = "valid-token"
+
+// stubGenerator installs every module of the spec from its regular channel.
+type stubGenerator struct{}
+
+func (stubGenerator) DryRunModulesFromTemplate(_ context.Context, kyma *v1beta2.Kyma) (common.Modules, error) {
+	modules := make(common.Modules, 0, len(kyma.Spec.Modules))
+	for _, module := range kyma.Spec.Modules {
+		template := &channel.ModuleTemplateTO{ModuleTemplate: newTemplate(module.Name+"-regular", "regular", 1)}
+		modules = append(modules, newModule(module.Name, "1.0.0", template))
+	}
+	return modules, nil
+}
+
+type stubAuthorizer struct {
+	err error
+}
+
+func (a stubAuthorizer) Authorize(*http.Request, types.NamespacedName) error {
+	return a.err
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta2.AddToScheme(scheme))
+	return scheme
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	kyma := &v1beta2.Kyma{
+		ObjectMeta: metav1.ObjectMeta{Name: "kyma", Namespace: namespace},
+		Spec:       v1beta2.KymaSpec{Modules: []v1beta2.Module{{Name: "current"}}},
+	}
+	tests := []struct {
+		name        string
+		method      string
+		query       string
+		body        string
+		authorizer  plan.Authorizer
+		wantStatus  int
+		wantModules []string
+	}{
+		{
+			name: "current spec", method: http.MethodGet, query: "namespace=kcp-system&name=kyma",
+			authorizer: stubAuthorizer{}, wantStatus: http.StatusOK, wantModules: []string{"current"},
+		},
+		{
+			name: "proposed spec", method: http.MethodPost, query: "namespace=kcp-system&name=kyma",
+			body:       `{"modules":[{"name":"proposed"}]}`,
+			authorizer: stubAuthorizer{}, wantStatus: http.StatusOK, wantModules: []string{"proposed"},
+		},
+		{
+			name: "invalid proposed spec", method: http.MethodPost, query: "namespace=kcp-system&name=kyma",
+			body: `{"modules":`, authorizer: stubAuthorizer{}, wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown kyma", method: http.MethodGet, query: "namespace=kcp-system&name=unknown",
+			authorizer: stubAuthorizer{}, wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "missing name", method: http.MethodGet, query: "namespace=kcp-system",
+			authorizer: stubAuthorizer{}, wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "method not allowed", method: http.MethodDelete, query: "namespace=kcp-system&name=kyma",
+			authorizer: stubAuthorizer{}, wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "unauthenticated", method: http.MethodGet, query: "namespace=kcp-system&name=kyma",
+			authorizer: stubAuthorizer{err: plan.ErrUnauthenticated}, wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "forbidden", method: http.MethodGet, query: "namespace=kcp-system&name=kyma",
+			authorizer: stubAuthorizer{err: plan.ErrForbidden}, wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "without authorizer", method: http.MethodGet, query: "namespace=kcp-system&name=kyma",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			handler := &plan.Handler{
+				Reader:     fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(kyma.DeepCopy()).Build(),
+				Generator:  stubGenerator{},
+				Authorizer: testCase.authorizer,
+			}
+			req := httptest.NewRequest(testCase.method, plan.Path+"?"+testCase.query, strings.NewReader(testCase.body))
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			require.Equal(t, testCase.wantStatus, recorder.Code, recorder.Body.String())
+			if testCase.wantStatus != http.StatusOK {
+				return
+			}
+			result := &plan.Plan{}
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(result))
+			modules := make([]string, 0, len(result.Modules))
+			for _, module := range result.Modules {
+				modules = append(modules, module.Name)
+			}
+			assert.Equal(t, testCase.wantModules, modules)
+		})
+	}
+}
+
+// reviewingClient admits the validToken of alice, who may only get Kymas.
+func reviewingClient(t *testing.T) client.Client {
+	t.Helper()
+	return fake.NewClientBuilder().WithScheme(newScheme(t)).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if review.Spec.Token == validToken {
+					review.Status.Authenticated = true
+					review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"developers"}}
+				}
+			case *authorizationv1.SubjectAccessReview:
+				attributes := review.Spec.ResourceAttributes
+				review.Status.Allowed = review.Spec.User == "alice" && attributes.Verb == "get" &&
+					attributes.Group == v1beta2.GroupVersion.Group && attributes.Resource == "kymas" &&
+					attributes.Namespace == namespace && attributes.Name == "kyma"
+			}
+			return nil
+		},
+	}).Build()
+}
+
+func TestAccessReviewAuthorizer_Authorize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		method  string
+		header  string
+		wantErr error
+	}{
+		{name: "allowed", method: http.MethodGet, header: "Bearer " + validToken},
+		{name: "missing token", method: http.MethodGet, wantErr: plan.ErrUnauthenticated},
+		{name: "invalid token", method: http.MethodGet, header: "Bearer invalid", wantErr: plan.ErrUnauthenticated},
+		{
+			name: "proposed spec without update permission", method: http.MethodPost,
+			header: "Bearer " + validToken, wantErr: plan.ErrForbidden,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			authorizer := &plan.AccessReviewAuthorizer{Client: reviewingClient(t)}
+			req := httptest.NewRequest(testCase.method, plan.Path, nil)
+			if testCase.header != "" {
+				req.Header.Set("Authorization", testCase.header)
+			}
+
+			err := authorizer.Authorize(req, types.NamespacedName{Namespace: namespace, Name: "kyma"})
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/signature"
+)
+
+var errMoreThanOneTemplate = errors.New("more than one ModuleTemplate found")
+
+// MaxHistory is the number of promotions kept in the status of a ModuleChannelPromotion.
+const MaxHistory = 10
+
+// Promoter copies the descriptor of a module from the ModuleTemplate of the source channel
+// to the ModuleTemplate of the target channel, as declared by a ModuleChannelPromotion.
+type Promoter struct {
+	client.Client
+	enableVerification bool
+	publicKeyFilePath  string
+	now                func() time.Time
+}
+
+func NewPromoter(clnt client.Client, settings signature.VerificationSettings) *Promoter {
+	return &Promoter{
+		Client:             clnt,
+		enableVerification: settings.EnableVerification,
+		publicKeyFilePath:  settings.PublicKeyFilePath,
+		now:                time.Now,
+	}
+}
+
+// Promote promotes the version of the source channel once it soaked for the SoakTime and updates the status
+// of the promotion accordingly. Versions are never decremented in the target channel. The returned duration
+// is the time left until the version in the source channel can be promoted, zero if there is nothing to wait for.
+func (p *Promoter) Promote(ctx context.Context, promotion *v1beta2.ModuleChannelPromotion) (time.Duration, error) {
+	spec, status := promotion.Spec, &promotion.Status
+
+	source, err := p.findTemplate(ctx, promotion.GetNamespace(), spec.Module, spec.SourceChannel)
+	if err != nil {
+		setState(status, v1beta2.PromotionStateError, err.Error())
+		return 0, err
+	}
+	if source == nil {
+		setState(status, v1beta2.PromotionStateError, fmt.Sprintf("no ModuleTemplate found for module %s in channel %s",
+			spec.Module, spec.SourceChannel))
+		return 0, nil
+	}
+	version, err := p.verifiedVersion(ctx, source, spec.Module)
+	if err != nil {
+		setState(status, v1beta2.PromotionStateBlocked, fmt.Sprintf("ModuleTemplate %s cannot be promoted: %s",
+			source.GetName(), err))
+		return 0, nil
+	}
+	soakStart, err := p.soakStart(ctx, source, version.Original())
+	if err != nil {
+		setState(status, v1beta2.PromotionStateError, err.Error())
+		return 0, err
+	}
+	status.SourceVersion = version.Original()
+	status.SourceObservedSince = metav1.NewTime(soakStart)
+	if spec.Paused {
+		setState(status, v1beta2.PromotionStatePaused, "promotion is paused")
+		return 0, nil
+	}
+
+	target, err := p.findTemplate(ctx, promotion.GetNamespace(), spec.Module, spec.TargetChannel)
+	if err != nil {
+		setState(status, v1beta2.PromotionStateError, err.Error())
+		return 0, err
+	}
+	if target != nil {
+		targetVersion, err := templateVersion(target)
+		if err != nil {
+			setState(status, v1beta2.PromotionStateBlocked, fmt.Sprintf("ModuleTemplate %s cannot be updated: %s",
+				target.GetName(), err))
+			return 0, nil
+		}
+		if targetVersion.Equal(version) {
+			status.PromotedVersion = version.Original()
+			setState(status, v1beta2.PromotionStatePromoted, fmt.Sprintf("version %s is available in channel %s",
+				version.Original(), spec.TargetChannel))
+			return 0, nil
+		}
+		if targetVersion.GreaterThan(version) {
+			setState(status, v1beta2.PromotionStateBlocked, fmt.Sprintf(
+				"version %s of channel %s is lower than version %s of channel %s, the version must not be decremented",
+				version.Original(), spec.SourceChannel, targetVersion.Original(), spec.TargetChannel))
+			return 0, nil
+		}
+	}
+
+	promotableAt := status.SourceObservedSince.Add(spec.SoakTime.Duration)
+	if remaining := promotableAt.Sub(p.now()); remaining > 0 {
+		setState(status, v1beta2.PromotionStateSoaking, fmt.Sprintf("version %s soaks in channel %s until %s",
+			version.Original(), spec.SourceChannel, promotableAt.UTC().Format(time.RFC3339)))
+		return remaining, nil
+	}
+
+	if target == nil {
+		target = &v1beta2.ModuleTemplate{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", spec.Module, spec.TargetChannel),
+			Namespace: promotion.GetNamespace(),
+		}}
+	}
+	if err := p.copyTemplate(ctx, source, target, spec.TargetChannel); err != nil {
+		setState(status, v1beta2.PromotionStateError, err.Error())
+		return 0, err
+	}
+
+	status.PromotedVersion = version.Original()
+	status.History = append([]v1beta2.PromotionRecord{{
+		Version:        version.Original(),
+		SourceTemplate: source.GetName(),
+		TargetTemplate: target.GetName(),
+		PromotedAt:     metav1.NewTime(p.now()),
+	}}, status.History...)
+	if len(status.History) > MaxHistory {
+		status.History = status.History[:MaxHistory]
+	}
+	setState(status, v1beta2.PromotionStatePromoted, fmt.Sprintf("version %s is promoted to channel %s",
+		version.Original(), spec.TargetChannel))
+	return 0, nil
+}
+
+// soakStart returns since when the version is available in the channel of the source ModuleTemplate.
+// It is persisted in the SoakStartAnnotation of the ModuleTemplate when the version is first observed,
+// so that the soak time is neither restarted by a recreated promotion nor by a lost status.
+func (p *Promoter) soakStart(ctx context.Context, source *v1beta2.ModuleTemplate, version string) (time.Time, error) {
+	annotatedVersion, annotatedStart, found := strings.Cut(source.GetAnnotations()[v1beta2.SoakStartAnnotation], "@")
+	if found && annotatedVersion == version {
+		if start, err := time.Parse(time.RFC3339Nano, annotatedStart); err == nil {
+			return start, nil
+		}
+	}
+
+	start := p.now().UTC()
+	patch := client.MergeFrom(source.DeepCopy())
+	annotations := source.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[v1beta2.SoakStartAnnotation] = fmt.Sprintf("%s@%s", version, start.Format(time.RFC3339Nano))
+	source.SetAnnotations(annotations)
+	if err := p.Patch(ctx, source, patch); err != nil {
+		return time.Time{}, fmt.Errorf("failed to record soak start of ModuleTemplate %s: %w", source.GetName(), err)
+	}
+	return start, nil
+}
+
+// findTemplate returns the ModuleTemplate of the module in the given channel or nil if there is none.
+func (p *Promoter) findTemplate(ctx context.Context, namespace, module, channel string,
+) (*v1beta2.ModuleTemplate, error) {
+	templateList := &v1beta2.ModuleTemplateList{}
+	if err := p.List(ctx, templateList, client.InNamespace(namespace),
+		client.MatchingLabels{v1beta2.ModuleName: module}); err != nil {
+		return nil, fmt.Errorf("failed to list ModuleTemplates of module %s: %w", module, err)
+	}
+
+	var found *v1beta2.ModuleTemplate
+	for i := range templateList.Items {
+		template := &templateList.Items[i]
+		if template.Spec.Channel != channel || !template.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("%w for module %s in channel %s: %s, %s", errMoreThanOneTemplate,
+				module, channel, found.GetName(), template.GetName())
+		}
+		found = template
+	}
+	return found, nil
+}
+
+// verifiedVersion returns the version of the descriptor if its signature can be verified.
+func (p *Promoter) verifiedVersion(ctx context.Context, template *v1beta2.ModuleTemplate, module string,
+) (*semver.Version, error) {
+	descriptor, err := template.GetDescriptor()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get descriptor: %w", err)
+	}
+	verification, err := signature.NewVerification(ctx, p.Client, p.enableVerification, p.publicKeyFilePath, module)
+	if err != nil {
+		return nil, err
+	}
+	if err := signature.Verify(descriptor.ComponentDescriptor, verification); err != nil {
+		return nil, err
+	}
+	return templateVersion(template)
+}
+
+func (p *Promoter) copyTemplate(ctx context.Context, source, target *v1beta2.ModuleTemplate, channel string) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, p.Client, target, func() error {
+		target.SetLabels(mergeMaps(target.GetLabels(), source.GetLabels()))
+		annotations := mergeMaps(target.GetAnnotations(), source.GetAnnotations())
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		// the version only starts to soak in the target channel once it is promoted.
+		delete(annotations, v1beta2.SoakStartAnnotation)
+		target.SetAnnotations(annotations)
+		target.Spec = *source.Spec.DeepCopy()
+		target.Spec.Channel = channel
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to promote ModuleTemplate %s to %s: %w", source.GetName(), target.GetName(), err)
+	}
+	return nil
+}
+
+func templateVersion(template *v1beta2.ModuleTemplate) (*semver.Version, error) {
+	descriptor, err := template.GetDescriptor()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get descriptor: %w", err)
+	}
+	version, err := semver.NewVersion(descriptor.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q in descriptor: %w", descriptor.Version, err)
+	}
+	return version, nil
+}
+
+func mergeMaps(target, source map[string]string) map[string]string {
+	merged := make(map[string]string, len(target)+len(source))
+	for key, value := range target {
+		merged[key] = value
+	}
+	for key, value := range source {
+		merged[key] = value
+	}
+	return merged
+}
+
+func setState(status *v1beta2.ModuleChannelPromotionStatus, state v1beta2.PromotionState, message string) {
+	status.State = state
+	status.Message = message
+}
@@ -0,0 +1,149 @@
+package promotion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	compdescv2 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/versions/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/testutils/builder"
+)
+
+const (
+	namespace  = "kcp-system"
+	moduleName = "test-module"
+)
+
+func newModuleTemplate(channel, version string) *v1beta2.ModuleTemplate {
+	return builder.NewModuleTemplateBuilder().
+		WithName(moduleName + "-" + channel).
+		WithNamespace(namespace).
+		WithModuleName(moduleName).
+		WithChannel(channel).
+		WithOCM(compdescv2.SchemaVersion).
+		WithDescriptorVersion(version).
+		Build()
+}
+
+func newPromoter(t *testing.T, now *time.Time, templates ...client.Object) *Promoter {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta2.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(templates...).Build()
+	return &Promoter{Client: clnt, now: func() time.Time { return *now }}
+}
+
+func newPromotion() *v1beta2.ModuleChannelPromotion {
+	return &v1beta2.ModuleChannelPromotion{
+		ObjectMeta: metav1.ObjectMeta{Name: "promotion", Namespace: namespace},
+		Spec: v1beta2.ModuleChannelPromotionSpec{
+			Module:        moduleName,
+			SourceChannel: "fast",
+			TargetChannel: "regular",
+			SoakTime:      metav1.Duration{Duration: time.Hour},
+		},
+	}
+}
+
+func TestPromoter_PromotesAfterSoakTime(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	promoter := newPromoter(t, &now, newModuleTemplate("fast", "1.1.0"))
+	promotion := newPromotion()
+	ctx := context.Background()
+
+	requeueAfter, err := promoter.Promote(ctx, promotion)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, requeueAfter)
+	assert.Equal(t, v1beta2.PromotionStateSoaking, promotion.Status.State)
+	assert.Equal(t, "1.1.0", promotion.Status.SourceVersion)
+
+	now = now.Add(time.Hour)
+	requeueAfter, err = promoter.Promote(ctx, promotion)
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+	assert.Equal(t, v1beta2.PromotionStatePromoted, promotion.Status.State)
+	assert.Equal(t, "1.1.0", promotion.Status.PromotedVersion)
+	require.Len(t, promotion.Status.History, 1)
+	assert.Equal(t, moduleName+"-fast", promotion.Status.History[0].SourceTemplate)
+	assert.Equal(t, moduleName+"-regular", promotion.Status.History[0].TargetTemplate)
+
+	target := &v1beta2.ModuleTemplate{}
+	require.NoError(t, promoter.Get(ctx, client.ObjectKey{Namespace: namespace, Name: moduleName + "-regular"}, target))
+	assert.Equal(t, "regular", target.Spec.Channel)
+	assert.Equal(t, moduleName, target.Labels[v1beta2.ModuleName])
+	assert.NotContains(t, target.Annotations, v1beta2.SoakStartAnnotation,
+		"the version only starts to soak in the target channel once it is promoted")
+	version, err := templateVersion(target)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", version.Original())
+
+	_, err = promoter.Promote(ctx, promotion)
+	require.NoError(t, err)
+	assert.Len(t, promotion.Status.History, 1, "an already promoted version is not promoted again")
+}
+
+func TestPromoter_NeverDecrementsTargetVersion(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	promoter := newPromoter(t, &now, newModuleTemplate("fast", "1.1.0"), newModuleTemplate("regular", "1.2.0"))
+	promotion := newPromotion()
+	promotion.Spec.SoakTime = metav1.Duration{}
+
+	_, err := promoter.Promote(context.Background(), promotion)
+	require.NoError(t, err)
+	assert.Equal(t, v1beta2.PromotionStateBlocked, promotion.Status.State)
+	assert.Empty(t, promotion.Status.History)
+
+	target := &v1beta2.ModuleTemplate{}
+	require.NoError(t, promoter.Get(context.Background(),
+		client.ObjectKey{Namespace: namespace, Name: moduleName + "-regular"}, target))
+	version, err := templateVersion(target)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", version.Original())
+}
+
+func TestPromoter_RestartsSoakTimeForNewVersion(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	source := newModuleTemplate("fast", "1.2.0")
+	promoter := newPromoter(t, &now, source, newModuleTemplate("regular", "1.0.0"))
+	promotion := newPromotion()
+	promotion.Status.SourceVersion = "1.1.0"
+	promotion.Status.SourceObservedSince = metav1.NewTime(now.Add(-2 * time.Hour))
+
+	requeueAfter, err := promoter.Promote(context.Background(), promotion)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, requeueAfter)
+	assert.Equal(t, v1beta2.PromotionStateSoaking, promotion.Status.State)
+	assert.Equal(t, "1.2.0", promotion.Status.SourceVersion)
+	assert.True(t, promotion.Status.SourceObservedSince.Time.Equal(now))
+}
+
+func TestPromoter_KeepsSoakStartOfRecreatedPromotion(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	promoter := newPromoter(t, &now, newModuleTemplate("fast", "1.1.0"))
+	ctx := context.Background()
+
+	_, err := promoter.Promote(ctx, newPromotion())
+	require.NoError(t, err)
+	source := &v1beta2.ModuleTemplate{}
+	require.NoError(t, promoter.Get(ctx, client.ObjectKey{Namespace: namespace, Name: moduleName + "-fast"}, source))
+	assert.Contains(t, source.Annotations[v1beta2.SoakStartAnnotation], "1.1.0@")
+
+	now = now.Add(30 * time.Minute)
+	recreated := newPromotion()
+	requeueAfter, err := promoter.Promote(ctx, recreated)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, requeueAfter, "the soak time is counted from the first observation")
+	assert.Equal(t, v1beta2.PromotionStateSoaking, recreated.Status.State)
+}
@@ -0,0 +1,187 @@
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+)
+
+var (
+	errRolloutPaused          = errors.New("rollout is paused")
+	errRolloutWindowExhausted = errors.New("rollout window is exhausted")
+)
+
+// countInterval is how often the adopting Kymas of a rollout are counted, so that the status of a ModuleRollout
+// is not updated on every reconciliation of a Kyma using its ModuleTemplate.
+const countInterval = 30 * time.Second
+
+// Gate limits how fast Kymas adopt a new generation of a ModuleTemplate based on the ModuleRollout
+// referencing the ModuleTemplate. ModuleTemplates without a ModuleRollout are not limited.
+type Gate struct {
+	client.Client
+	now    func() time.Time
+	dryRun bool
+}
+
+func NewGate(clnt client.Client) *Gate {
+	return &Gate{Client: clnt, now: time.Now}
+}
+
+// NewDryRunGate creates a Gate that decides like a Gate created by NewGate, but never updates
+// the status of a ModuleRollout. A Kyma it admits is not recorded, so it uses no slot of the rollout window.
+func NewDryRunGate(clnt client.Client) *Gate {
+	return &Gate{Client: clnt, now: time.Now, dryRun: true}
+}
+
+// Check decides if the Kyma may adopt the current generation of the template, given the module status that
+// tracks the generation the Kyma currently uses. A Kyma has adopted the generation once it is tracked in its
+// module status, i.e. once it was applied. The adopting Kymas are counted periodically to pause the rollout
+// once too many of them report the module in Error.
+// The status of the ModuleRollout is only updated if the rollout restarts, a window starts, a Kyma is admitted
+// or the Kymas are counted, so Kymas that already adopted the generation or have to wait do not update it.
+// If the Kyma has to wait, an error wrapping channel.ErrTemplateUpdateDeferred is returned.
+func (g *Gate) Check(ctx context.Context, kyma *v1beta2.Kyma, template *v1beta2.ModuleTemplate,
+	moduleStatus *v1beta2.ModuleStatus,
+) error {
+	key, err := g.findRollout(ctx, template)
+	if err != nil || key == nil {
+		return err
+	}
+
+	kymaKey := client.ObjectKeyFromObject(kyma).String()
+	var admissionErr error
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		rollout := &v1beta2.ModuleRollout{}
+		if err := g.Get(ctx, *key, rollout); err != nil {
+			return fmt.Errorf("failed to get module rollout %s: %w", key, err)
+		}
+		status := rollout.Status.DeepCopy()
+		now := g.now()
+		if status.TargetGeneration != template.GetGeneration() {
+			*status = v1beta2.ModuleRolloutStatus{
+				TargetGeneration: template.GetGeneration(),
+				WindowStart:      metav1.NewTime(now),
+			}
+		}
+		if now.Sub(status.CountedAt.Time) >= countInterval {
+			adopted, failed, err := g.countKymas(ctx, template)
+			if err != nil {
+				return err
+			}
+			status.AdoptedKymas, status.FailedKymas, status.CountedAt = adopted, failed, metav1.NewTime(now)
+		}
+		admissionErr = admit(rollout.Spec, status, kymaKey, moduleStatus, now)
+		updateState(rollout.Spec, status)
+		if g.dryRun || equality.Semantic.DeepEqual(status, &rollout.Status) {
+			return nil
+		}
+		rollout.Status = *status
+		return g.Status().Update(ctx, rollout)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update module rollout %s: %w", key, err)
+	}
+	if admissionErr != nil {
+		return fmt.Errorf("%w: module rollout %s: %w", channel.ErrTemplateUpdateDeferred, key, admissionErr)
+	}
+	return nil
+}
+
+func (g *Gate) findRollout(ctx context.Context, template *v1beta2.ModuleTemplate) (*client.ObjectKey, error) {
+	rollouts := &v1beta2.ModuleRolloutList{}
+	if err := g.List(ctx, rollouts, client.InNamespace(template.GetNamespace())); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list module rollouts: %w", err)
+	}
+	for i := range rollouts.Items {
+		if rollouts.Items[i].Spec.Template == template.GetName() {
+			key := client.ObjectKeyFromObject(&rollouts.Items[i])
+			return &key, nil
+		}
+	}
+	return nil, nil
+}
+
+// countKymas counts the Kymas whose module status tracks the current generation of the template,
+// and how many of them report the module in Error.
+func (g *Gate) countKymas(ctx context.Context, template *v1beta2.ModuleTemplate) (int, int, error) {
+	kymas := &v1beta2.KymaList{}
+	if err := g.List(ctx, kymas); err != nil {
+		return 0, 0, fmt.Errorf("failed to list kymas: %w", err)
+	}
+	adopted, failed := 0, 0
+	for i := range kymas.Items {
+		for _, moduleStatus := range kymas.Items[i].Status.Modules {
+			tracked := moduleStatus.Template
+			if tracked == nil || tracked.GetNamespace() != template.GetNamespace() ||
+				tracked.GetName() != template.GetName() || tracked.GetGeneration() != template.GetGeneration() {
+				continue
+			}
+			adopted++
+			if moduleStatus.State == shared.StateError {
+				failed++
+			}
+		}
+	}
+	return adopted, failed, nil
+}
+
+// admit admits the Kyma to adopt the TargetGeneration unless the rollout is paused or the window is exhausted.
+// Kymas that already adopted the generation or were admitted in the current window are admitted again.
+func admit(spec v1beta2.ModuleRolloutSpec, status *v1beta2.ModuleRolloutStatus, kymaKey string,
+	moduleStatus *v1beta2.ModuleStatus, now time.Time,
+) error {
+	if moduleStatus.Template.GetGeneration() == status.TargetGeneration ||
+		slices.Contains(status.AdmittedKymas, kymaKey) {
+		return nil
+	}
+
+	if spec.Paused || status.FailedKymas > spec.MaxErrors {
+		return fmt.Errorf("%w: %s", errRolloutPaused, pauseMessage(spec, status))
+	}
+	if now.Sub(status.WindowStart.Time) >= spec.Window.Duration {
+		status.WindowStart = metav1.NewTime(now)
+		status.AdmittedKymas = nil
+	}
+	if len(status.AdmittedKymas) >= spec.MaxKymasPerWindow {
+		return fmt.Errorf("%w: %d kymas were admitted to adopt generation %d, next window starts at %s",
+			errRolloutWindowExhausted, len(status.AdmittedKymas), status.TargetGeneration,
+			status.WindowStart.Add(spec.Window.Duration).Format(time.RFC3339))
+	}
+
+	status.AdmittedKymas = append(status.AdmittedKymas, kymaKey)
+	return nil
+}
+
+func updateState(spec v1beta2.ModuleRolloutSpec, status *v1beta2.ModuleRolloutStatus) {
+	if spec.Paused || status.FailedKymas > spec.MaxErrors {
+		status.State = v1beta2.RolloutStatePaused
+		status.Message = pauseMessage(spec, status)
+		return
+	}
+	status.State = v1beta2.RolloutStateProgressing
+	status.Message = fmt.Sprintf("%d kymas adopted generation %d, %d admitted in the current window",
+		status.AdoptedKymas, status.TargetGeneration, len(status.AdmittedKymas))
+}
+
+func pauseMessage(spec v1beta2.ModuleRolloutSpec, status *v1beta2.ModuleRolloutStatus) string {
+	if spec.Paused {
+		return "rollout is paused manually"
+	}
+	return fmt.Sprintf("%d adopting kymas report the module in %s, which exceeds %d",
+		status.FailedKymas, shared.StateError, spec.MaxErrors)
+}
@@ -0,0 +1,214 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/testutils/builder"
+)
+
+const (
+	namespace    = "kcp-system"
+	templateName = "template"
+	moduleName   = "module"
+)
+
+// newGate creates a Gate for a ModuleRollout of the template and counts the status updates of the rollout.
+func newGate(t *testing.T, spec v1beta2.ModuleRolloutSpec, now *time.Time, kymas ...*v1beta2.Kyma,
+) (*Gate, client.ObjectKey, *int) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta2.AddToScheme(scheme))
+	spec.Template = templateName
+	rollout := &v1beta2.ModuleRollout{
+		ObjectMeta: metav1.ObjectMeta{Name: "rollout", Namespace: namespace},
+		Spec:       spec,
+	}
+	objs := []client.Object{rollout}
+	for _, kyma := range kymas {
+		objs = append(objs, kyma)
+	}
+	updates := 0
+	clnt := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(objs...).WithStatusSubresource(rollout).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, clnt client.Client, subResourceName string,
+				obj client.Object, opts ...client.SubResourceUpdateOption,
+			) error {
+				updates++
+				return clnt.SubResource(subResourceName).Update(ctx, obj, opts...) //nolint:wrapcheck
+			},
+		}).Build()
+	return &Gate{Client: clnt, now: func() time.Time { return *now }}, client.ObjectKeyFromObject(rollout), &updates
+}
+
+func getRollout(t *testing.T, gate *Gate, key client.ObjectKey) *v1beta2.ModuleRollout {
+	t.Helper()
+	rollout := &v1beta2.ModuleRollout{}
+	require.NoError(t, gate.Get(context.Background(), key, rollout))
+	return rollout
+}
+
+func TestGate_LimitsKymasPerWindow(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	gate, key, _ := newGate(t, v1beta2.ModuleRolloutSpec{
+		MaxKymasPerWindow: 1,
+		Window:            metav1.Duration{Duration: time.Hour},
+		MaxErrors:         1,
+	}, &now)
+	previous := builder.NewModuleTemplateBuilder().
+		WithName(templateName).WithNamespace(namespace).WithGeneration(1).Build()
+	template := builder.NewModuleTemplateBuilder().
+		WithName(templateName).WithNamespace(namespace).WithGeneration(2).Build()
+	moduleStatus := builder.NewModuleStatusBuilder().
+		WithName(moduleName).WithTemplate(previous).WithState(shared.StateReady).Build()
+	first := builder.NewKymaBuilder().WithName("first").WithNamespace(namespace).Build()
+	second := builder.NewKymaBuilder().WithName("second").WithNamespace(namespace).Build()
+	ctx := context.Background()
+
+	require.NoError(t, gate.Check(ctx, first, template, moduleStatus))
+	require.NoError(t, gate.Check(ctx, first, template, moduleStatus),
+		"an admitted kyma stays admitted until it adopted the generation")
+	err := gate.Check(ctx, second, template, moduleStatus)
+	require.ErrorIs(t, err, channel.ErrTemplateUpdateDeferred)
+	require.ErrorIs(t, err, errRolloutWindowExhausted)
+
+	now = now.Add(time.Hour)
+	require.NoError(t, gate.Check(ctx, second, template, moduleStatus))
+
+	rollout := getRollout(t, gate, key)
+	assert.Equal(t, int64(2), rollout.Status.TargetGeneration)
+	assert.Equal(t, []string{namespace + "/second"}, rollout.Status.AdmittedKymas)
+	assert.Equal(t, v1beta2.RolloutStateProgressing, rollout.Status.State)
+}
+
+func TestGate_CountsAdoptedKymas(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	previous := builder.NewModuleTemplateBuilder().
+		WithName(templateName).WithNamespace(namespace).WithGeneration(1).Build()
+	template := builder.NewModuleTemplateBuilder().
+		WithName(templateName).WithNamespace(namespace).WithGeneration(2).Build()
+	adopted := builder.NewModuleStatusBuilder().
+		WithName(moduleName).WithTemplate(template).WithState(shared.StateReady).Build()
+	pending := builder.NewModuleStatusBuilder().
+		WithName(moduleName).WithTemplate(previous).WithState(shared.StateReady).Build()
+	installed := builder.NewKymaBuilder().WithName("installed").WithNamespace(namespace).
+		WithModuleStatus(adopted).Build()
+	gate, key, updates := newGate(t, v1beta2.ModuleRolloutSpec{
+		MaxKymasPerWindow: 1,
+		Window:            metav1.Duration{Duration: time.Hour},
+		MaxErrors:         1,
+	}, &now, installed, builder.NewKymaBuilder().WithName("pending").WithNamespace(namespace).
+		WithModuleStatus(pending).Build())
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, gate.Check(ctx, installed, template, adopted))
+	}
+
+	rollout := getRollout(t, gate, key)
+	assert.Equal(t, 1, rollout.Status.AdoptedKymas, "kymas already on the generation are counted")
+	assert.Empty(t, rollout.Status.AdmittedKymas, "kymas already on the generation use no slot of the window")
+	assert.Equal(t, 1, *updates, "the status is only updated when the rollout starts")
+
+	require.NoError(t, gate.Check(ctx, builder.NewKymaBuilder().WithName("pending").WithNamespace(namespace).Build(),
+		template, pending))
+	rollout = getRollout(t, gate, key)
+	assert.Equal(t, 1, rollout.Status.AdoptedKymas, "admitted kymas are only counted once they applied the generation")
+	assert.Equal(t, 2, *updates)
+}
+
+func TestGate_PausesOnErrors(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	previous := builder.NewModuleTemplateBuilder().
+		WithName(templateName).WithNamespace(namespace).WithGeneration(1).Build()
+	template := builder.NewModuleTemplateBuilder().
+		WithName(templateName).WithNamespace(namespace).WithGeneration(2).Build()
+	failing := builder.NewKymaBuilder().WithName("first").WithNamespace(namespace).
+		WithModuleStatus(builder.NewModuleStatusBuilder().
+			WithName(moduleName).WithTemplate(template).WithState(shared.StateError).Build()).
+		Build()
+	gate, key, _ := newGate(t, v1beta2.ModuleRolloutSpec{
+		MaxKymasPerWindow: 10,
+		Window:            metav1.Duration{Duration: time.Hour},
+		MaxErrors:         0,
+	}, &now, failing)
+	second := builder.NewKymaBuilder().WithName("second").WithNamespace(namespace).Build()
+	moduleStatus := builder.NewModuleStatusBuilder().
+		WithName(moduleName).WithTemplate(previous).WithState(shared.StateReady).Build()
+	ctx := context.Background()
+
+	err := gate.Check(ctx, second, template, moduleStatus)
+	require.ErrorIs(t, err, errRolloutPaused)
+
+	rollout := getRollout(t, gate, key)
+	assert.Equal(t, v1beta2.RolloutStatePaused, rollout.Status.State)
+	assert.Equal(t, 1, rollout.Status.AdoptedKymas)
+	assert.Equal(t, 1, rollout.Status.FailedKymas)
+
+	recovered := &v1beta2.Kyma{}
+	require.NoError(t, gate.Get(ctx, client.ObjectKeyFromObject(failing), recovered))
+	recovered.Status.Modules[0].State = shared.StateReady
+	require.NoError(t, gate.Update(ctx, recovered))
+	err = gate.Check(ctx, second, template, moduleStatus)
+	require.ErrorIs(t, err, errRolloutPaused, "the kymas are only recounted after the count interval")
+
+	now = now.Add(countInterval)
+	require.NoError(t, gate.Check(ctx, second, template, moduleStatus))
+	assert.Equal(t, v1beta2.RolloutStateProgressing, getRollout(t, gate, key).Status.State)
+}
+
+func TestGate_IgnoresTemplatesWithoutRollout(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	gate, _, _ := newGate(t, v1beta2.ModuleRolloutSpec{MaxKymasPerWindow: 1}, &now)
+	previous := builder.NewModuleTemplateBuilder().
+		WithName("other").WithNamespace(namespace).WithGeneration(1).Build()
+	template := builder.NewModuleTemplateBuilder().
+		WithName("other").WithNamespace(namespace).WithGeneration(2).Build()
+
+	require.NoError(t, gate.Check(context.Background(),
+		builder.NewKymaBuilder().WithName("first").WithNamespace(namespace).Build(), template,
+		builder.NewModuleStatusBuilder().WithName(moduleName).WithTemplate(previous).WithState(shared.StateReady).Build()))
+}
+
+func TestGate_DryRunDoesNotRecordAdmissions(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	gate, key, updates := newGate(t, v1beta2.ModuleRolloutSpec{
+		MaxKymasPerWindow: 1,
+		Window:            metav1.Duration{Duration: time.Hour},
+		MaxErrors:         1,
+	}, &now)
+	gate.dryRun = true
+	previous := builder.NewModuleTemplateBuilder().
+		WithName(templateName).WithNamespace(namespace).WithGeneration(1).Build()
+	template := builder.NewModuleTemplateBuilder().
+		WithName(templateName).WithNamespace(namespace).WithGeneration(2).Build()
+	moduleStatus := builder.NewModuleStatusBuilder().
+		WithName(moduleName).WithTemplate(previous).WithState(shared.StateReady).Build()
+	ctx := context.Background()
+
+	require.NoError(t, gate.Check(ctx, builder.NewKymaBuilder().WithName("first").WithNamespace(namespace).Build(),
+		template, moduleStatus))
+	require.NoError(t, gate.Check(ctx, builder.NewKymaBuilder().WithName("second").WithNamespace(namespace).Build(),
+		template, moduleStatus))
+
+	assert.Empty(t, getRollout(t, gate, key).Status.AdmittedKymas)
+	assert.Zero(t, *updates)
+}
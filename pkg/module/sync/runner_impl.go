@@ -2,6 +2,7 @@ package sync
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	ctrlLog "sigs.k8s.io/controller-runtime/pkg/log"
@@ -25,6 +27,9 @@ import (
 	"github.com/kyma-project/lifecycle-manager/pkg/util"
 )
 
+// ModulePausedMessage is the message of the status of a paused module.
+const ModulePausedMessage = "module is paused"
+
 func New(clnt client.Client) *RunnerImpl {
 	return &RunnerImpl{
 		Client:    clnt,
@@ -40,25 +45,64 @@ type RunnerImpl struct {
 }
 
 // ReconcileManifests implements Runner.Sync.
+// Modules are reconciled level by level in the order of their dependencies, see common.ResolveDependencies.
 func (r *RunnerImpl) ReconcileManifests(ctx context.Context, kyma *v1beta2.Kyma,
 	modules common.Modules,
 ) error {
 	ssaStart := time.Now()
 	baseLogger := ctrlLog.FromContext(ctx)
 
+	moduleStatusMap := kyma.GetModuleStatusMap()
+	var errs []error
+	for _, level := range common.ResolveDependencies(modules) {
+		errs = append(errs, r.reconcileManifests(ctx, kyma, level, moduleStatusMap)...)
+	}
+	ssaFinish := time.Since(ssaStart)
+	if len(errs) != 0 {
+		errs = append(errs, fmt.Errorf("ServerSideApply failed (after %s)", ssaFinish)) //nolint:goerr113
+		return errors.Join(errs...)
+	}
+	baseLogger.V(log.DebugLevel).Info("ServerSideApply finished", "time", ssaFinish)
+	return nil
+}
+
+func (r *RunnerImpl) reconcileManifests(ctx context.Context, kyma *v1beta2.Kyma,
+	modules common.Modules, moduleStatusMap map[string]*v1beta2.ModuleStatus,
+) []error {
+	baseLogger := ctrlLog.FromContext(ctx)
+
 	results := make(chan error, len(modules))
 	for _, module := range modules {
 		go func(module *common.Module) {
+			// A paused module keeps its Manifest as it is, including its resources.
+			if module.IsPaused(kyma) {
+				results <- r.pauseManifest(ctx, module, moduleStatusMap[module.ModuleName])
+				return
+			}
 			// Due to module template visibility change, some module previously deployed should be removed.
 			if errors.Is(module.Template.Err, channel.ErrTemplateNotAllowed) {
 				results <- r.deleteManifest(ctx, module)
 				return
 			}
+			// An orphaned module keeps its Manifest until the orphan policy uninstalls it.
+			if orphan := module.Template.Orphan; orphan != nil && orphan.Uninstall {
+				results <- r.deleteOrphanedManifest(ctx, moduleStatusMap[module.ModuleName])
+				return
+			}
 			// Module template in other error status should be ignored.
 			if module.Template.Err != nil {
 				results <- nil
 				return
 			}
+			// A module is only installed once all of its dependencies are ready.
+			if moduleStatus, installed := moduleStatusMap[module.ModuleName]; !installed ||
+				moduleStatus.Manifest == nil {
+				if err := module.CheckDependenciesReady(); err != nil {
+					module.Template.Err = err
+					results <- nil
+					return
+				}
+			}
 			if err := r.updateManifests(ctx, kyma, module); err != nil {
 				results <- fmt.Errorf("could not update module %s: %w", module.GetName(), err)
 				return
@@ -73,13 +117,7 @@ func (r *RunnerImpl) ReconcileManifests(ctx context.Context, kyma *v1beta2.Kyma,
 			errs = append(errs, err)
 		}
 	}
-	ssaFinish := time.Since(ssaStart)
-	if len(errs) != 0 {
-		errs = append(errs, fmt.Errorf("ServerSideApply failed (after %s)", ssaFinish)) //nolint:goerr113
-		return errors.Join(errs...)
-	}
-	baseLogger.V(log.DebugLevel).Info("ServerSideApply finished", "time", ssaFinish)
-	return nil
+	return errs
 }
 
 func (r *RunnerImpl) getModule(ctx context.Context, module client.Object) error {
@@ -96,6 +134,9 @@ func (r *RunnerImpl) updateManifests(ctx context.Context, kyma *v1beta2.Kyma,
 	if err := r.setupModule(module, kyma); err != nil {
 		return err
 	}
+	if err := r.resumeManifest(ctx, module); err != nil {
+		return err
+	}
 	obj, err := r.converter.ConvertToVersion(module.Object, r.versioner)
 	if err != nil {
 		return fmt.Errorf("failed to convert object to version: %w", err)
@@ -116,6 +157,63 @@ func (r *RunnerImpl) updateManifests(ctx context.Context, kyma *v1beta2.Kyma,
 	return nil
 }
 
+// pauseManifest labels the installed Manifest of the module with v1beta2.PausedLabel, so that its resources
+// are neither applied nor pruned. Apart from the label, the Manifest is not patched.
+func (r *RunnerImpl) pauseManifest(ctx context.Context, module *common.Module,
+	moduleStatus *v1beta2.ModuleStatus,
+) error {
+	if moduleStatus == nil || moduleStatus.Manifest == nil {
+		// a paused module is not installed.
+		return nil
+	}
+	manifest := &v1beta2.Manifest{}
+	key := client.ObjectKey{Namespace: moduleStatus.Manifest.GetNamespace(), Name: moduleStatus.Manifest.GetName()}
+	if err := r.Get(ctx, key, manifest); err != nil {
+		if util.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get manifest %s: %w", key, err)
+	}
+	if manifest.GetLabels()[v1beta2.PausedLabel] != v1beta2.EnableLabelValue {
+		if err := r.patchPausedLabel(ctx, manifest, v1beta2.EnableLabelValue); err != nil {
+			return err
+		}
+	}
+	module.Object = manifest
+	return nil
+}
+
+// resumeManifest removes v1beta2.PausedLabel from the Manifest of a module that is no longer paused.
+func (r *RunnerImpl) resumeManifest(ctx context.Context, module *common.Module) error {
+	manifest := &v1beta2.Manifest{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(module), manifest); err != nil {
+		if util.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get manifest %s: %w", client.ObjectKeyFromObject(module), err)
+	}
+	if _, paused := manifest.GetLabels()[v1beta2.PausedLabel]; !paused {
+		return nil
+	}
+	return r.patchPausedLabel(ctx, manifest, nil)
+}
+
+// patchPausedLabel sets or removes (if value is nil) the label with a merge patch, as an apply
+// of only the label would drop the ownership of all other fields of the Manifest.
+func (r *RunnerImpl) patchPausedLabel(ctx context.Context, manifest *v1beta2.Manifest, value any) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"labels": map[string]any{v1beta2.PausedLabel: value}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create patch for manifest %s: %w", client.ObjectKeyFromObject(manifest), err)
+	}
+	if err := r.Patch(ctx, manifest, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to patch label %s of manifest %s: %w", v1beta2.PausedLabel,
+			client.ObjectKeyFromObject(manifest), err)
+	}
+	return nil
+}
+
 func (r *RunnerImpl) deleteManifest(ctx context.Context, module *common.Module) error {
 	err := r.Delete(ctx, module.Object)
 	if util.IsNotFound(err) {
@@ -124,6 +222,20 @@ func (r *RunnerImpl) deleteManifest(ctx context.Context, module *common.Module)
 	return fmt.Errorf("failed to delete manifest: %w", err)
 }
 
+// deleteOrphanedManifest deletes the Manifest tracked in the status of a module whose ModuleTemplate was deleted.
+func (r *RunnerImpl) deleteOrphanedManifest(ctx context.Context, moduleStatus *v1beta2.ModuleStatus) error {
+	if moduleStatus == nil || moduleStatus.Manifest == nil {
+		return nil
+	}
+	manifest := &v1beta2.Manifest{}
+	manifest.SetName(moduleStatus.Manifest.GetName())
+	manifest.SetNamespace(moduleStatus.Manifest.GetNamespace())
+	if err := r.Delete(ctx, manifest); err != nil && !util.IsNotFound(err) {
+		return fmt.Errorf("failed to delete orphaned manifest %s: %w", client.ObjectKeyFromObject(manifest), err)
+	}
+	return nil
+}
+
 func (r *RunnerImpl) setupModule(module *common.Module, kyma *v1beta2.Kyma) error {
 	// set labels
 	module.ApplyLabelsAndAnnotations(kyma)
@@ -153,7 +265,13 @@ func (r *RunnerImpl) updateModuleStatusFromExistingModules(
 	for idx := range modules {
 		module := modules[idx]
 		moduleStatus, exists := moduleStatusMap[module.ModuleName]
-		latestModuleStatus := generateModuleStatus(module, moduleStatus)
+		var latestModuleStatus v1beta2.ModuleStatus
+		if module.IsPaused(kyma) {
+			latestModuleStatus = generatePausedModuleStatus(module, moduleStatus)
+		} else {
+			latestModuleStatus = generateModuleStatus(module, moduleStatus)
+		}
+		latestModuleStatus.Criticality = module.Criticality(kyma)
 		if exists {
 			*moduleStatus = latestModuleStatus
 		} else {
@@ -169,6 +287,34 @@ func generateModuleStatus(module *common.Module, existStatus *v1beta2.ModuleStat
 		newModuleStatus.Message = module.Template.Err.Error()
 		return *newModuleStatus
 	}
+	if errors.Is(module.Template.Err, channel.ErrTemplateUpdateDeferred) {
+		// the installed module is kept as is until the update is no longer deferred.
+		newModuleStatus := existStatus.DeepCopy()
+		newModuleStatus.Message = module.Template.Err.Error()
+		return *newModuleStatus
+	}
+	if errors.Is(module.Template.Err, common.ErrDependencyNotReady) {
+		return v1beta2.ModuleStatus{
+			Name:    module.ModuleName,
+			Channel: module.Template.DesiredChannel,
+			FQDN:    module.FQDN,
+			State:   shared.StateProcessing,
+			Message: module.Template.Err.Error(),
+			History: moduleHistory(existStatus),
+		}
+	}
+	if existStatus != nil && (errors.Is(module.Template.Err, common.ErrDependencyMissing) ||
+		errors.Is(module.Template.Err, common.ErrDependencyCycle) ||
+		errors.Is(module.Template.Err, channel.ErrInvalidAudience)) {
+		// keep tracking the already installed module so that it can still be deleted.
+		newModuleStatus := existStatus.DeepCopy()
+		newModuleStatus.State = shared.StateError
+		newModuleStatus.Message = module.Template.Err.Error()
+		return *newModuleStatus
+	}
+	if module.Template.Orphan != nil && existStatus != nil {
+		return generateOrphanedModuleStatus(module, existStatus)
+	}
 	if module.Template.Err != nil {
 		return v1beta2.ModuleStatus{
 			Name:    module.ModuleName,
@@ -176,6 +322,7 @@ func generateModuleStatus(module *common.Module, existStatus *v1beta2.ModuleStat
 			FQDN:    module.FQDN,
 			State:   shared.StateError,
 			Message: module.Template.Err.Error(),
+			History: moduleHistory(existStatus),
 		}
 	}
 	manifestObject, ok := module.Object.(*v1beta2.Manifest)
@@ -187,6 +334,7 @@ func generateModuleStatus(module *common.Module, existStatus *v1beta2.ModuleStat
 			FQDN:    module.FQDN,
 			State:   shared.StateError,
 			Message: ErrManifestConversion.Error(),
+			History: moduleHistory(existStatus),
 		}
 	}
 
@@ -206,12 +354,28 @@ func generateModuleStatus(module *common.Module, existStatus *v1beta2.ModuleStat
 		}
 	}
 
+	// a rollback stays recorded until the module changes its version again.
+	rolledBackFrom := module.Template.RollbackFrom
+	if rolledBackFrom == "" && existStatus != nil && existStatus.Version == module.Version {
+		rolledBackFrom = existStatus.RolledBackFrom
+	}
+
+	// the requested channel is only reported if an alias or a fallback resolved it to another channel.
+	var requestedChannel string
+	if module.Template.ResolvedBy == v1beta2.ModuleResolvedByChannel &&
+		module.Template.DesiredChannel != module.Template.Spec.Channel {
+		requestedChannel = module.Template.DesiredChannel
+	}
+
 	return v1beta2.ModuleStatus{
-		Name:    module.ModuleName,
-		FQDN:    module.FQDN,
-		State:   manifestObject.Status.State,
-		Channel: module.Template.Spec.Channel,
-		Version: module.Version,
+		Name:             module.ModuleName,
+		FQDN:             module.FQDN,
+		State:            manifestObject.Status.State,
+		Channel:          module.Template.Spec.Channel,
+		RequestedChannel: requestedChannel,
+		Version:          module.Version,
+		ResolvedBy:       module.Template.ResolvedBy,
+		RolledBackFrom:   rolledBackFrom,
 		Manifest: &v1beta2.TrackingObject{
 			PartialMeta: v1beta2.PartialMetaFromObject(manifestObject),
 			TypeMeta:    metav1.TypeMeta{Kind: manifestKind, APIVersion: manifestAPIVersion},
@@ -221,7 +385,84 @@ func generateModuleStatus(module *common.Module, existStatus *v1beta2.ModuleStat
 			TypeMeta:    metav1.TypeMeta{Kind: templateKind, APIVersion: templateAPIVersion},
 		},
 		Resource: moduleResource,
+		History:  recordTransition(existStatus, module),
+	}
+}
+
+// recordTransition prepends a transition to the history of the existing status if the module is installed in or
+// changed to another version or channel. The history is bounded to v1beta2.MaxModuleHistory transitions.
+func recordTransition(existStatus *v1beta2.ModuleStatus, module *common.Module) []v1beta2.ModuleTransition {
+	history := moduleHistory(existStatus)
+	version, moduleChannel := module.Version, module.Template.Spec.Channel
+	// the latest transition is compared instead of the existing status, as statuses reporting an error
+	// do not track the version, and statuses created before the history was introduced have none.
+	if (len(history) > 0 && history[0].Version == version && history[0].Channel == moduleChannel) ||
+		(len(history) == 0 && existStatus != nil &&
+			existStatus.Version == version && existStatus.Channel == moduleChannel) {
+		return history
+	}
+	transition := v1beta2.ModuleTransition{
+		Version:            version,
+		Channel:            moduleChannel,
+		TemplateGeneration: module.Template.GetGeneration(),
+		TransitionTime:     metav1.Now(),
+	}
+	history = append([]v1beta2.ModuleTransition{transition}, history...)
+	if len(history) > v1beta2.MaxModuleHistory {
+		history = history[:v1beta2.MaxModuleHistory]
+	}
+	return history
+}
+
+func moduleHistory(existStatus *v1beta2.ModuleStatus) []v1beta2.ModuleTransition {
+	if existStatus == nil {
+		return nil
+	}
+	return existStatus.History
+}
+
+// generateOrphanedModuleStatus keeps tracking an installed module whose ModuleTemplate was deleted, so that its
+// Manifest is still deleted together with the Kyma, and reports the action of the orphan policy.
+func generateOrphanedModuleStatus(module *common.Module, existStatus *v1beta2.ModuleStatus) v1beta2.ModuleStatus {
+	orphan := module.Template.Orphan
+	newModuleStatus := existStatus.DeepCopy()
+	newModuleStatus.OrphanedSince = orphan.Since.DeepCopy()
+	newModuleStatus.State = shared.StateWarning
+	switch {
+	case orphan.Uninstall:
+		newModuleStatus.Manifest = nil
+		newModuleStatus.Resource = nil
+		newModuleStatus.Message = module.Template.Err.Error() + ", the module is uninstalled"
+	case orphan.Action == v1beta2.OrphanActionUninstall:
+		newModuleStatus.Message = fmt.Sprintf("%s, the module is uninstalled at %s",
+			module.Template.Err, orphan.UninstallAt.Format(time.RFC3339))
+	case orphan.Action == v1beta2.OrphanActionFreeze:
+		newModuleStatus.Message = module.Template.Err.Error() + ", the module is frozen"
+	default:
+		newModuleStatus.State = shared.StateError
+		newModuleStatus.Message = module.Template.Err.Error()
+	}
+	return *newModuleStatus
+}
+
+// generatePausedModuleStatus keeps the status of the installed module, only the state is updated from its Manifest.
+func generatePausedModuleStatus(module *common.Module, existStatus *v1beta2.ModuleStatus) v1beta2.ModuleStatus {
+	if existStatus == nil || existStatus.Manifest == nil {
+		return v1beta2.ModuleStatus{
+			Name:    module.ModuleName,
+			Channel: module.Template.DesiredChannel,
+			FQDN:    module.FQDN,
+			State:   shared.StateWarning,
+			Message: ModulePausedMessage + ", it is not installed until it is resumed",
+			History: moduleHistory(existStatus),
+		}
+	}
+	newModuleStatus := existStatus.DeepCopy()
+	if state := stateFromManifest(module.Object); state != "" {
+		newModuleStatus.State = state
 	}
+	newModuleStatus.Message = ModulePausedMessage
+	return *newModuleStatus
 }
 
 func stateFromManifest(obj client.Object) shared.State {
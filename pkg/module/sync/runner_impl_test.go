@@ -2,15 +2,20 @@ package sync_test
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"testing"
 
+	"github.com/kyma-project/lifecycle-manager/api/shared"
 	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/kyma-project/lifecycle-manager/pkg/channel"
+	"github.com/kyma-project/lifecycle-manager/pkg/module/common"
 	"github.com/kyma-project/lifecycle-manager/pkg/module/sync"
 	"github.com/kyma-project/lifecycle-manager/pkg/testutils"
 	"github.com/stretchr/testify/assert"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -115,3 +120,150 @@ func TestDeleteNoLongerExistingModuleStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncModuleStatus_PausedModule(t *testing.T) {
+	t.Parallel()
+	kyma := testutils.NewTestKyma("test-kyma")
+	kyma.Spec.Modules = []v1beta2.Module{{Name: "installed", Paused: true}, {Name: "new", Paused: true}}
+	kyma.Status.Modules = []v1beta2.ModuleStatus{{
+		Name:     "installed",
+		Version:  "1.0.0",
+		State:    shared.StateProcessing,
+		Manifest: &v1beta2.TrackingObject{PartialMeta: v1beta2.PartialMeta{Name: "installed"}},
+	}, {
+		Name:    "new",
+		History: []v1beta2.ModuleTransition{{Version: "0.9.0"}},
+	}}
+	manifest := &v1beta2.Manifest{Status: shared.Status{State: shared.StateReady}}
+	template := &channel.ModuleTemplateTO{
+		ModuleTemplate: &v1beta2.ModuleTemplate{},
+		DesiredChannel: v1beta2.DefaultChannel,
+	}
+	modules := common.Modules{
+		{ModuleName: "installed", Version: "2.0.0", Template: template, Object: manifest},
+		{ModuleName: "new", Version: "2.0.0", Template: template, Object: &v1beta2.Manifest{}},
+	}
+
+	(&sync.RunnerImpl{}).SyncModuleStatus(context.TODO(), kyma, modules)
+
+	statusMap := kyma.GetModuleStatusMap()
+	assert.Equal(t, "1.0.0", statusMap["installed"].Version, "the version of a paused module is kept")
+	assert.Equal(t, shared.StateReady, statusMap["installed"].State)
+	assert.Equal(t, sync.ModulePausedMessage, statusMap["installed"].Message)
+	assert.Equal(t, shared.StateWarning, statusMap["new"].State)
+	assert.Nil(t, statusMap["new"].Manifest)
+	assert.Equal(t, []v1beta2.ModuleTransition{{Version: "0.9.0"}}, statusMap["new"].History,
+		"the history of a module that is not installed is kept")
+}
+
+func TestSyncModuleStatus_History(t *testing.T) {
+	t.Parallel()
+	kyma := testutils.NewTestKyma("test-kyma")
+	kyma.Spec.Modules = []v1beta2.Module{{Name: "module"}}
+	template := &channel.ModuleTemplateTO{
+		ModuleTemplate: &v1beta2.ModuleTemplate{Spec: v1beta2.ModuleTemplateSpec{Channel: v1beta2.DefaultChannel}},
+		DesiredChannel: v1beta2.DefaultChannel,
+	}
+	syncVersion := func(version string) []v1beta2.ModuleTransition {
+		template.Generation++
+		modules := common.Modules{
+			{ModuleName: "module", Version: version, Template: template, Object: &v1beta2.Manifest{}},
+		}
+		(&sync.RunnerImpl{}).SyncModuleStatus(context.TODO(), kyma, modules)
+		return kyma.GetModuleStatusMap()["module"].History
+	}
+
+	history := syncVersion("1.0.0")
+	assert.Len(t, history, 1)
+	assert.Equal(t, "1.0.0", history[0].Version)
+	assert.Equal(t, v1beta2.DefaultChannel, history[0].Channel)
+	assert.Len(t, syncVersion("1.0.0"), 1, "an unchanged version is not recorded again")
+
+	history = syncVersion("1.1.0")
+	assert.Len(t, history, 2)
+	assert.Equal(t, "1.1.0", history[0].Version, "the most recent transition comes first")
+	assert.Equal(t, template.Generation, history[0].TemplateGeneration)
+
+	for _, version := range []string{"1.2.0", "1.3.0", "1.4.0", "1.5.0"} {
+		history = syncVersion(version)
+	}
+	assert.Len(t, history, v1beta2.MaxModuleHistory)
+	assert.Equal(t, "1.5.0", history[0].Version)
+	assert.Equal(t, "1.1.0", history[v1beta2.MaxModuleHistory-1].Version)
+}
+
+func TestSyncModuleStatus_OrphanedModule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		orphan       channel.Orphan
+		wantState    shared.State
+		wantManifest bool
+	}{
+		{"frozen", channel.Orphan{Action: v1beta2.OrphanActionFreeze}, shared.StateWarning, true},
+		{"uninstall pending", channel.Orphan{Action: v1beta2.OrphanActionUninstall}, shared.StateWarning, true},
+		{
+			"uninstalled", channel.Orphan{Action: v1beta2.OrphanActionUninstall, Uninstall: true},
+			shared.StateWarning, false,
+		},
+		{"failed", channel.Orphan{Action: v1beta2.OrphanActionFail}, shared.StateError, true},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			kyma := testutils.NewTestKyma("test-kyma")
+			kyma.Spec.Modules = []v1beta2.Module{{Name: "module"}}
+			kyma.Status.Modules = []v1beta2.ModuleStatus{{
+				Name:     "module",
+				Version:  "1.0.0",
+				State:    shared.StateReady,
+				Manifest: &v1beta2.TrackingObject{PartialMeta: v1beta2.PartialMeta{Name: "module"}},
+				Template: &v1beta2.TrackingObject{PartialMeta: v1beta2.PartialMeta{Name: "module-regular"}},
+			}}
+			orphan := testCase.orphan
+			orphan.Since = metav1.Now()
+			template := &channel.ModuleTemplateTO{
+				Err:    fmt.Errorf("%w: kcp-system/module-regular", channel.ErrTemplateDeleted),
+				Orphan: &orphan,
+			}
+			modules := common.Modules{{ModuleName: "module", Template: template}}
+
+			(&sync.RunnerImpl{}).SyncModuleStatus(context.TODO(), kyma, modules)
+
+			moduleStatus := kyma.GetModuleStatusMap()["module"]
+			assert.Equal(t, testCase.wantState, moduleStatus.State)
+			assert.Equal(t, "1.0.0", moduleStatus.Version)
+			assert.Equal(t, testCase.wantManifest, moduleStatus.Manifest != nil)
+			assert.NotNil(t, moduleStatus.OrphanedSince)
+			assert.Contains(t, moduleStatus.Message, channel.ErrTemplateDeleted.Error())
+		})
+	}
+}
+
+func TestSyncModuleStatus_BlockedChannelMigration(t *testing.T) {
+	t.Parallel()
+	kyma := testutils.NewTestKyma("test-kyma")
+	kyma.Spec.Modules = []v1beta2.Module{{Name: "module"}}
+	kyma.Status.Modules = []v1beta2.ModuleStatus{{
+		Name:    "module",
+		Channel: "alpha",
+		Version: "2.0.0",
+		State:   shared.StateReady,
+	}}
+	template := &channel.ModuleTemplateTO{
+		ModuleTemplate: &v1beta2.ModuleTemplate{Spec: v1beta2.ModuleTemplateSpec{Channel: v1beta2.DefaultChannel}},
+		DesiredChannel: "alpha",
+		Err:            fmt.Errorf("%w: ignore channel skew (from alpha to regular)", channel.ErrTemplateUpdateNotAllowed),
+	}
+	modules := common.Modules{{ModuleName: "module", Template: template}}
+
+	(&sync.RunnerImpl{}).SyncModuleStatus(context.TODO(), kyma, modules)
+
+	moduleStatus := kyma.GetModuleStatusMap()["module"]
+	assert.Equal(t, shared.StateWarning, moduleStatus.State)
+	assert.Equal(t, "alpha", moduleStatus.Channel, "the module stays on the deprecated channel")
+	assert.Equal(t, "2.0.0", moduleStatus.Version)
+	assert.Equal(t, template.Err.Error(), moduleStatus.Message)
+}
@@ -86,6 +86,18 @@ func (kb KymaBuilder) WithCondition(condition metav1.Condition) KymaBuilder {
 	return kb
 }
 
+// WithModule adds a v1beta2.Module to v1beta2.Kyma.Spec.Modules.
+func (kb KymaBuilder) WithModule(module v1beta2.Module) KymaBuilder {
+	kb.kyma.Spec.Modules = append(kb.kyma.Spec.Modules, module)
+	return kb
+}
+
+// WithModuleStatus adds a v1beta2.ModuleStatus to v1beta2.Kyma.Status.Modules.
+func (kb KymaBuilder) WithModuleStatus(moduleStatus *v1beta2.ModuleStatus) KymaBuilder {
+	kb.kyma.Status.Modules = append(kb.kyma.Status.Modules, *moduleStatus)
+	return kb
+}
+
 // Build returns the built v1beta2.Kyma.
 func (kb KymaBuilder) Build() *v1beta2.Kyma {
 	return kb.kyma
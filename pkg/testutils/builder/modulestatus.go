@@ -0,0 +1,64 @@
+package builder
+
+import (
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+)
+
+type ModuleStatusBuilder struct {
+	moduleStatus *v1beta2.ModuleStatus
+}
+
+// NewModuleStatusBuilder returns a ModuleStatusBuilder with v1beta2.ModuleStatus initialized defaults.
+func NewModuleStatusBuilder() ModuleStatusBuilder {
+	return ModuleStatusBuilder{
+		moduleStatus: &v1beta2.ModuleStatus{
+			Name: RandomName(),
+		},
+	}
+}
+
+// WithName sets v1beta2.ModuleStatus.Name.
+func (mb ModuleStatusBuilder) WithName(name string) ModuleStatusBuilder {
+	mb.moduleStatus.Name = name
+	return mb
+}
+
+// WithChannel sets v1beta2.ModuleStatus.Channel.
+func (mb ModuleStatusBuilder) WithChannel(channel string) ModuleStatusBuilder {
+	mb.moduleStatus.Channel = channel
+	return mb
+}
+
+// WithVersion sets v1beta2.ModuleStatus.Version.
+func (mb ModuleStatusBuilder) WithVersion(version string) ModuleStatusBuilder {
+	mb.moduleStatus.Version = version
+	return mb
+}
+
+// WithState sets v1beta2.ModuleStatus.State.
+func (mb ModuleStatusBuilder) WithState(state shared.State) ModuleStatusBuilder {
+	mb.moduleStatus.State = state
+	return mb
+}
+
+// WithManifest sets v1beta2.ModuleStatus.Manifest to track the Manifest with the given name.
+func (mb ModuleStatusBuilder) WithManifest(name string) ModuleStatusBuilder {
+	mb.moduleStatus.Manifest = &v1beta2.TrackingObject{PartialMeta: v1beta2.PartialMeta{Name: name}}
+	return mb
+}
+
+// WithTemplate sets v1beta2.ModuleStatus.Template to track the current generation of the v1beta2.ModuleTemplate.
+func (mb ModuleStatusBuilder) WithTemplate(template *v1beta2.ModuleTemplate) ModuleStatusBuilder {
+	mb.moduleStatus.Template = &v1beta2.TrackingObject{PartialMeta: v1beta2.PartialMeta{
+		Name:       template.GetName(),
+		Namespace:  template.GetNamespace(),
+		Generation: template.GetGeneration(),
+	}}
+	return mb
+}
+
+// Build returns the built v1beta2.ModuleStatus.
+func (mb ModuleStatusBuilder) Build() *v1beta2.ModuleStatus {
+	return mb.moduleStatus
+}
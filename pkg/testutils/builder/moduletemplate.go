@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -83,6 +84,41 @@ func (m ModuleTemplateBuilder) WithOCM(schemaVersion compdesc.SchemaVersion) Mod
 	return m
 }
 
+// WithDescriptorVersion sets the version of the component descriptor of schema version v2 set by WithOCM.
+func (m ModuleTemplateBuilder) WithDescriptorVersion(version string) ModuleTemplateBuilder {
+	var descriptor map[string]any
+	if err := json.Unmarshal(m.moduleTemplate.Spec.Descriptor.Raw, &descriptor); err != nil {
+		panic(fmt.Errorf("unmarshal component descriptor: %w", err))
+	}
+	component, ok := descriptor["component"].(map[string]any)
+	if !ok {
+		panic("component descriptor has no component")
+	}
+	component["version"] = version
+	raw, err := json.Marshal(descriptor)
+	if err != nil {
+		panic(fmt.Errorf("marshal component descriptor: %w", err))
+	}
+	m.moduleTemplate.Spec.Descriptor = k8sruntime.RawExtension{Raw: raw}
+	return m
+}
+
+func (m ModuleTemplateBuilder) WithNamespace(namespace string) ModuleTemplateBuilder {
+	m.moduleTemplate.ObjectMeta.Namespace = namespace
+	return m
+}
+
+func (m ModuleTemplateBuilder) WithGeneration(generation int64) ModuleTemplateBuilder {
+	m.moduleTemplate.ObjectMeta.Generation = generation
+	return m
+}
+
+// WithAudience sets v1beta2.ModuleTemplate.Spec.Audience.
+func (m ModuleTemplateBuilder) WithAudience(audience *metav1.LabelSelector) ModuleTemplateBuilder {
+	m.moduleTemplate.Spec.Audience = audience
+	return m
+}
+
 func (m ModuleTemplateBuilder) WithOCMPrivateRepo() ModuleTemplateBuilder {
 	if m.moduleTemplate.Labels == nil {
 		m.moduleTemplate.Labels = make(map[string]string)